@@ -0,0 +1,398 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+)
+
+// Condition types specific to Linode managed resources.
+const (
+	// TypeMigration Instances are moving, or have moved, between regions.
+	TypeMigration runtimev1alpha1.ConditionType = "Migration"
+
+	// TypePowerState Instances are powered on, as opposed to powered off or
+	// changing power state.
+	TypePowerState runtimev1alpha1.ConditionType = "PowerState"
+
+	// TypeBootable Instances have a Config to boot. Instances created without
+	// an image have none until an InstanceConfig is added.
+	TypeBootable runtimev1alpha1.ConditionType = "Bootable"
+
+	// TypeDeletionProtection Instances are protected from deletion.
+	TypeDeletionProtection runtimev1alpha1.ConditionType = "DeletionProtection"
+
+	// TypePaused resources are not reconciled, because they are annotated
+	// with crossplane.io/paused=true.
+	TypePaused runtimev1alpha1.ConditionType = "Paused"
+
+	// TypeScopes Providers have credentials with the OAuth scopes required to
+	// manage every kind of Linode managed resource.
+	TypeScopes runtimev1alpha1.ConditionType = "Scopes"
+)
+
+// Reasons an Instance is or is not migrating.
+const (
+	ReasonMigrating       runtimev1alpha1.ConditionReason = "Linode Instance is migrating to a new region"
+	ReasonMigrated        runtimev1alpha1.ConditionReason = "Linode Instance has migrated to the requested region"
+	ReasonMigrationFailed runtimev1alpha1.ConditionReason = "Linode Instance failed to migrate to a new region"
+)
+
+// Migrating returns a condition that indicates the Instance is migrating to
+// the supplied region. The percentage of the migration that is complete is
+// recorded in the condition message.
+func Migrating(region string, percentComplete int) runtimev1alpha1.Condition {
+	return runtimev1alpha1.Condition{
+		Type:               TypeMigration,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonMigrating,
+		Message:            fmt.Sprintf("migrating to %s: %d%% complete", region, percentComplete),
+	}
+}
+
+// Migrated returns a condition that indicates the Instance has completed its
+// migration to the supplied region.
+func Migrated(region string) runtimev1alpha1.Condition {
+	return runtimev1alpha1.Condition{
+		Type:               TypeMigration,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonMigrated,
+		Message:            fmt.Sprintf("migrated to %s", region),
+	}
+}
+
+// MigrationFailed returns a condition that indicates the Instance failed to
+// migrate to the supplied region.
+func MigrationFailed(region string) runtimev1alpha1.Condition {
+	return runtimev1alpha1.Condition{
+		Type:               TypeMigration,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonMigrationFailed,
+		Message:            fmt.Sprintf("failed to migrate to %s", region),
+	}
+}
+
+// Reasons an Instance is or is not ready, by its Linode status.
+const (
+	ReasonInstanceRunning      runtimev1alpha1.ConditionReason = "Linode Instance is running"
+	ReasonInstanceOffline      runtimev1alpha1.ConditionReason = "Linode Instance is offline"
+	ReasonInstanceStopped      runtimev1alpha1.ConditionReason = "Linode Instance was stopped by Linode"
+	ReasonInstanceRescue       runtimev1alpha1.ConditionReason = "Linode Instance is in Rescue Mode"
+	ReasonInstanceProvisioning runtimev1alpha1.ConditionReason = "Linode Instance is being provisioned"
+	ReasonInstanceBooting      runtimev1alpha1.ConditionReason = "Linode Instance is booting"
+	ReasonInstanceRebooting    runtimev1alpha1.ConditionReason = "Linode Instance is rebooting"
+	ReasonInstanceShuttingDown runtimev1alpha1.ConditionReason = "Linode Instance is shutting down"
+	ReasonInstanceMigrating    runtimev1alpha1.ConditionReason = "Linode Instance is migrating"
+	ReasonInstanceRebuilding   runtimev1alpha1.ConditionReason = "Linode Instance is being rebuilt"
+	ReasonInstanceCloning      runtimev1alpha1.ConditionReason = "Linode Instance is being cloned"
+	ReasonInstanceRestoring    runtimev1alpha1.ConditionReason = "Linode Instance is being restored from a Backup"
+	ReasonInstanceResizing     runtimev1alpha1.ConditionReason = "Linode Instance is being resized"
+	ReasonInstanceDeleting     runtimev1alpha1.ConditionReason = "Linode Instance is being deleted"
+	ReasonInstanceUnknown      runtimev1alpha1.ConditionReason = "Linode Instance has an unknown status"
+)
+
+// instanceStatusReasons maps the Linode statuses of an Instance to the reason
+// it is or is not ready.
+var instanceStatusReasons = map[string]runtimev1alpha1.ConditionReason{
+	"running":             ReasonInstanceRunning,
+	"offline":             ReasonInstanceOffline,
+	InstanceStatusStopped: ReasonInstanceStopped,
+	InstanceStatusRescue:  ReasonInstanceRescue,
+	"provisioning":        ReasonInstanceProvisioning,
+	"booting":             ReasonInstanceBooting,
+	"rebooting":           ReasonInstanceRebooting,
+	"shutting_down":       ReasonInstanceShuttingDown,
+	"migrating":           ReasonInstanceMigrating,
+	"rebuilding":          ReasonInstanceRebuilding,
+	"cloning":             ReasonInstanceCloning,
+	"restoring":           ReasonInstanceRestoring,
+	"resizing":            ReasonInstanceResizing,
+	"deleting":            ReasonInstanceDeleting,
+}
+
+// InstanceReady returns the Ready condition of an Instance with the supplied
+// Linode status. The Instance is ready once its status is the desired status
+// of running, offline or rescue. Stopped Instances are offline.
+func InstanceReady(status, desired string) runtimev1alpha1.Condition {
+	reason, ok := instanceStatusReasons[status]
+	if !ok {
+		reason = ReasonInstanceUnknown
+	}
+
+	ready := status == desired || (status == InstanceStatusStopped && desired == "offline")
+	c := runtimev1alpha1.Condition{
+		Type:               runtimev1alpha1.TypeReady,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             reason,
+		Message:            fmt.Sprintf("Linode status is %s, desired status is %s", status, desired),
+	}
+	if ready {
+		c.Status = corev1.ConditionTrue
+	}
+	return c
+}
+
+// Reasons an Instance is or is not powered on.
+const (
+	ReasonPoweredOn     runtimev1alpha1.ConditionReason = "Linode Instance is powered on"
+	ReasonPoweredOff    runtimev1alpha1.ConditionReason = "Linode Instance is powered off"
+	ReasonPowerChanging runtimev1alpha1.ConditionReason = "Linode Instance is changing power state"
+)
+
+// InstancePowerState returns the PowerState condition of an Instance with the
+// supplied Linode status. Instances are powered on while running or in Rescue
+// Mode, powered off while offline or stopped, and otherwise changing power
+// state.
+func InstancePowerState(status string) runtimev1alpha1.Condition {
+	c := runtimev1alpha1.Condition{
+		Type:               TypePowerState,
+		Status:             corev1.ConditionUnknown,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonPowerChanging,
+		Message:            fmt.Sprintf("Linode status is %s", status),
+	}
+	switch status {
+	case "running", InstanceStatusRescue:
+		c.Status, c.Reason = corev1.ConditionTrue, ReasonPoweredOn
+	case "offline", InstanceStatusStopped:
+		c.Status, c.Reason = corev1.ConditionFalse, ReasonPoweredOff
+	}
+	return c
+}
+
+// Reasons a Provider is or is not ready.
+const (
+	ReasonCredentialsValid   runtimev1alpha1.ConditionReason = "Provider credentials are valid"
+	ReasonCredentialsMissing runtimev1alpha1.ConditionReason = "Provider credentials are missing"
+	ReasonCredentialsInvalid runtimev1alpha1.ConditionReason = "Provider credentials were rejected by the Linode API"
+)
+
+// Reasons a Provider does or does not have sufficient OAuth scopes.
+const (
+	ReasonScopesSufficient   runtimev1alpha1.ConditionReason = "Provider credentials have the required OAuth scopes"
+	ReasonScopesInsufficient runtimev1alpha1.ConditionReason = "Provider credentials are missing required OAuth scopes"
+)
+
+// CredentialsValid returns a condition that indicates the Provider's
+// credentials were accepted by the Linode API.
+func CredentialsValid() runtimev1alpha1.Condition {
+	return runtimev1alpha1.Condition{
+		Type:               runtimev1alpha1.TypeReady,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonCredentialsValid,
+	}
+}
+
+// CredentialsMissing returns a condition that indicates the Provider's
+// credentials could not be read. The supplied message explains why.
+func CredentialsMissing(message string) runtimev1alpha1.Condition {
+	return runtimev1alpha1.Condition{
+		Type:               runtimev1alpha1.TypeReady,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonCredentialsMissing,
+		Message:            message,
+	}
+}
+
+// CredentialsInvalid returns a condition that indicates the Provider's
+// credentials were rejected by the Linode API. The supplied message explains
+// why.
+func CredentialsInvalid(message string) runtimev1alpha1.Condition {
+	return runtimev1alpha1.Condition{
+		Type:               runtimev1alpha1.TypeReady,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonCredentialsInvalid,
+		Message:            message,
+	}
+}
+
+// ScopesSufficient returns a condition that indicates the Provider's
+// credentials have every required OAuth scope.
+func ScopesSufficient() runtimev1alpha1.Condition {
+	return runtimev1alpha1.Condition{
+		Type:               TypeScopes,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonScopesSufficient,
+	}
+}
+
+// ScopesInsufficient returns a condition that indicates the Provider's
+// credentials are missing required OAuth scopes. The supplied message lists
+// the missing scopes.
+func ScopesInsufficient(message string) runtimev1alpha1.Condition {
+	return runtimev1alpha1.Condition{
+		Type:               TypeScopes,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonScopesInsufficient,
+		Message:            message,
+	}
+}
+
+// Reasons an Instance can or cannot be booted.
+const (
+	ReasonBootable   runtimev1alpha1.ConditionReason = "Linode Instance has a Config to boot"
+	ReasonUnbootable runtimev1alpha1.ConditionReason = "Linode Instance has no Config to boot"
+)
+
+// Bootable returns a condition that indicates the Instance has a Config to
+// boot.
+func Bootable() runtimev1alpha1.Condition {
+	return runtimev1alpha1.Condition{
+		Type:               TypeBootable,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonBootable,
+	}
+}
+
+// Unbootable returns a condition that indicates the Instance is left offline,
+// despite its desired status, because it has no Config to boot.
+func Unbootable() runtimev1alpha1.Condition {
+	return runtimev1alpha1.Condition{
+		Type:               TypeBootable,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonUnbootable,
+		Message:            "set spec.image, or add an InstanceDisk and an InstanceConfig, to boot the Linode Instance",
+	}
+}
+
+// Reasons an Instance is or is not protected from deletion.
+const (
+	ReasonDeletionProtected   runtimev1alpha1.ConditionReason = "Linode Instance is protected from deletion"
+	ReasonDeletionRefused     runtimev1alpha1.ConditionReason = "Deletion of protected Linode Instance was refused"
+	ReasonDeletionUnprotected runtimev1alpha1.ConditionReason = "Linode Instance is not protected from deletion"
+)
+
+// DeletionProtected returns a condition that indicates the Instance is
+// protected from deletion.
+func DeletionProtected() runtimev1alpha1.Condition {
+	return runtimev1alpha1.Condition{
+		Type:               TypeDeletionProtection,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonDeletionProtected,
+	}
+}
+
+// DeletionRefused returns a condition that indicates the Instance was deleted
+// but its Linode Instance was not, because it is protected from deletion.
+func DeletionRefused() runtimev1alpha1.Condition {
+	return runtimev1alpha1.Condition{
+		Type:               TypeDeletionProtection,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonDeletionRefused,
+		Message:            fmt.Sprintf("set spec.deletionProtection to false, or annotate %s=false, to delete the Linode Instance", AnnotationDeletionProtection),
+	}
+}
+
+// DeletionUnprotected returns a condition that indicates the Instance is not
+// protected from deletion.
+func DeletionUnprotected() runtimev1alpha1.Condition {
+	return runtimev1alpha1.Condition{
+		Type:               TypeDeletionProtection,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonDeletionUnprotected,
+	}
+}
+
+// AnnotationPaused is set to "true" on any resource to stop the controller
+// from reconciling it, until the annotation is removed or set to "false".
+const AnnotationPaused = "crossplane.io/paused"
+
+// IsPaused returns true if the supplied resource is annotated as paused.
+func IsPaused(o metav1.Object) bool {
+	return o.GetAnnotations()[AnnotationPaused] == "true"
+}
+
+// Reasons a resource is or is not paused.
+const (
+	ReasonPaused  runtimev1alpha1.ConditionReason = "Reconciliation is paused"
+	ReasonResumed runtimev1alpha1.ConditionReason = "Reconciliation was resumed"
+)
+
+// Paused returns a condition that indicates reconciliation of the resource is
+// paused.
+func Paused() runtimev1alpha1.Condition {
+	return runtimev1alpha1.Condition{
+		Type:               TypePaused,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonPaused,
+		Message:            fmt.Sprintf("remove the %s annotation to resume reconciliation", AnnotationPaused),
+	}
+}
+
+// Resumed returns a condition that indicates reconciliation of the resource
+// was resumed after being paused.
+func Resumed() runtimev1alpha1.Condition {
+	return runtimev1alpha1.Condition{
+		Type:               TypePaused,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonResumed,
+	}
+}
+
+// ReconcilePaused returns a condition that indicates the resource is not
+// synced with its external resource, because reconciliation is paused.
+func ReconcilePaused() runtimev1alpha1.Condition {
+	return runtimev1alpha1.Condition{
+		Type:               runtimev1alpha1.TypeSynced,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonPaused,
+	}
+}
+
+// IsConditionTrue returns true if the supplied status has a condition of the
+// supplied type with a status of True.
+func IsConditionTrue(s runtimev1alpha1.ConditionedStatus, t runtimev1alpha1.ConditionType) bool {
+	for _, c := range s.Conditions {
+		if c.Type == t {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// GetCondition returns the condition of the supplied type from the supplied
+// status, or a condition with an Unknown status if there is none.
+func GetCondition(s runtimev1alpha1.ConditionedStatus, t runtimev1alpha1.ConditionType) runtimev1alpha1.Condition {
+	for _, c := range s.Conditions {
+		if c.Type == t {
+			return c
+		}
+	}
+	return runtimev1alpha1.Condition{Type: t, Status: corev1.ConditionUnknown}
+}
@@ -0,0 +1,229 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+)
+
+var (
+	ImageKind             = reflect.TypeOf(Image{}).Name()
+	ImageKindAPIVersion   = ImageKind + "." + GroupVersion.String()
+	ImageGroupVersionKind = GroupVersion.WithKind(ImageKind)
+)
+
+// ImagePersistentVolumeClaimSource identifies an image file on a
+// PersistentVolumeClaim in the same namespace as the Image
+type ImagePersistentVolumeClaimSource struct {
+	// ClaimName is the name of the PersistentVolumeClaim holding the image file
+	ClaimName string `json:"claimName"`
+
+	// Path is the path of the image file relative to the root of the volume
+	Path string `json:"path"`
+}
+
+// ImageUploadSource defines an image file to be uploaded to the Linode API.
+// Exactly one of URL or PersistentVolumeClaim must be set.
+type ImageUploadSource struct {
+	// Region is the Linode region the image file is uploaded to
+	Region string `json:"region"`
+
+	// URL is an HTTP(S) URL the gzip compressed raw disk image is downloaded from
+	// +optional
+	URL string `json:"url,omitempty"`
+
+	// PersistentVolumeClaim identifies a gzip compressed raw disk image on a
+	// PersistentVolumeClaim
+	// +optional
+	PersistentVolumeClaim *ImagePersistentVolumeClaimSource `json:"persistentVolumeClaim,omitempty"`
+}
+
+// ImageParameters define the desired state of a Linode Image. Exactly one of
+// DiskRef, DiskID, InstanceRef or Upload must be set.
+type ImageParameters struct {
+	// Label is the name of this Linode Image. The name of the Image is used when this is not set.
+	// +optional
+	Label string `json:"label,omitempty"`
+
+	// Description of this Linode Image
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// DiskRef references the InstanceDisk in the same namespace that this Image is captured from
+	// +optional
+	DiskRef *corev1.LocalObjectReference `json:"diskRef,omitempty"`
+
+	// DiskID is the ID of the Linode Instance Disk that this Image is captured from
+	// +optional
+	DiskID int `json:"diskID,omitempty"`
+
+	// InstanceRef references the Instance in the same namespace whose first
+	// non-swap Disk this Image is captured from
+	// +optional
+	InstanceRef *corev1.LocalObjectReference `json:"instanceRef,omitempty"`
+
+	// Upload defines an image file that is uploaded to create this Image
+	// +optional
+	Upload *ImageUploadSource `json:"upload,omitempty"`
+}
+
+// ImageSpec defines the desired state of Image
+type ImageSpec struct {
+	runtimev1alpha1.ResourceSpec `json:",inline"`
+	ImageParameters              `json:",inline"`
+
+	// ManagementPolicy determines whether the Linode Image is created, updated
+	// and deleted, or only observed. Defaults to Full. Images that are only
+	// observed find an existing private Linode Image by their label.
+	// +kubebuilder:validation:Enum=Full;ObserveOnly;NoDelete
+	// +optional
+	ManagementPolicy string `json:"managementPolicy,omitempty"`
+}
+
+// ImageStatus defines the observed state of Image
+type ImageStatus struct {
+	runtimev1alpha1.ResourceStatus `json:",inline"`
+
+	// Id is the unique identifier of a Linode Image, such as "private/1234"
+	// +optional
+	Id string `json:"id,omitempty"`
+
+	// Status is the current status of a Linode Image
+	// +optional
+	Status string `json:"status,omitempty"`
+
+	// Label is the name of a Linode Image
+	// +optional
+	Label string `json:"label,omitempty"`
+
+	// Description is the description of a Linode Image
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Size is the size of a Linode Image in MB
+	// +optional
+	Size int `json:"size,omitempty"`
+
+	// UploadJob is the name of the Job uploading the image file, if any
+	// +optional
+	UploadJob string `json:"uploadJob,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:printcolumn:name="ID",type="string",JSONPath=".status.id",description="ID of this Linode Image",priority=1
+// +kubebuilder:printcolumn:name="LABEL",type="string",JSONPath=".status.label",description="Label of this Linode Image",priority=1
+// +kubebuilder:printcolumn:name="STATUS",type="string",JSONPath=".status.status",description="Status of this Linode Image",priority=1
+
+// Image is the Schema for the images API
+type Image struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ImageSpec `json:"spec,omitempty"`
+	// +optional
+	Status ImageStatus `json:"status,omitempty"`
+}
+
+// GetProviderReference of this Image.
+func (a *Image) GetProviderReference() *corev1.ObjectReference {
+	return a.Spec.ProviderReference
+}
+
+// SetBindingPhase of this Image.
+func (a *Image) SetBindingPhase(p runtimev1alpha1.BindingPhase) {
+	a.Status.SetBindingPhase(p)
+}
+
+// GetBindingPhase of this Image.
+func (a *Image) GetBindingPhase() runtimev1alpha1.BindingPhase {
+	return a.Status.GetBindingPhase()
+}
+
+// SetConditions of this Image.
+func (a *Image) SetConditions(c ...runtimev1alpha1.Condition) {
+	a.Status.SetConditions(c...)
+}
+
+// GetCondition of this Image.
+func (a *Image) GetCondition(ct runtimev1alpha1.ConditionType) runtimev1alpha1.Condition {
+	return GetCondition(a.Status.ConditionedStatus, ct)
+}
+
+// SetClaimReference of this Image.
+func (a *Image) SetClaimReference(r *corev1.ObjectReference) {
+	a.Spec.ClaimReference = r
+}
+
+// GetClaimReference of this Image.
+func (a *Image) GetClaimReference() *corev1.ObjectReference {
+	return a.Spec.ClaimReference
+}
+
+// SetNonPortableClassReference of this Image.
+func (a *Image) SetNonPortableClassReference(r *corev1.ObjectReference) {
+	a.Spec.NonPortableClassReference = r
+}
+
+// GetNonPortableClassReference of this Image.
+func (a *Image) GetNonPortableClassReference() *corev1.ObjectReference {
+	return a.Spec.NonPortableClassReference
+}
+
+// SetWriteConnectionSecretToReference of this Image.
+func (a *Image) SetWriteConnectionSecretToReference(r corev1.LocalObjectReference) {
+	a.Spec.WriteConnectionSecretToReference = r
+}
+
+// GetWriteConnectionSecretToReference of this Image.
+func (a *Image) GetWriteConnectionSecretToReference() corev1.LocalObjectReference {
+	return a.Spec.WriteConnectionSecretToReference
+}
+
+// GetManagementPolicy of this Image.
+func (a *Image) GetManagementPolicy() string {
+	return a.Spec.ManagementPolicy
+}
+
+// GetReclaimPolicy of this Image.
+func (a *Image) GetReclaimPolicy() runtimev1alpha1.ReclaimPolicy {
+	return a.Spec.ReclaimPolicy
+}
+
+// SetReclaimPolicy of this Image.
+func (a *Image) SetReclaimPolicy(p runtimev1alpha1.ReclaimPolicy) {
+	a.Spec.ReclaimPolicy = p
+}
+
+// +kubebuilder:object:root=true
+
+// ImageList contains a list of Image
+type ImageList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Image `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Image{}, &ImageList{})
+}
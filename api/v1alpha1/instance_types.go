@@ -18,6 +18,7 @@ package v1alpha1
 
 import (
 	"reflect"
+	"strconv"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -34,6 +35,111 @@ var (
 	InstanceGroupVersionKind = GroupVersion.WithKind(InstanceKind)
 )
 
+const (
+	// AnnotationRebootRequestedAt is set on an Instance, typically to the
+	// current timestamp, to request that the Linode Instance be rebooted. The
+	// controller reboots the Instance once for each distinct value.
+	AnnotationRebootRequestedAt = Group + "/reboot-requested-at"
+
+	// AnnotationDeletionProtection is set on an Instance to "true" or "false"
+	// to override its deletionProtection, for example to delete a protected
+	// Instance without changing its spec.
+	AnnotationDeletionProtection = Group + "/deletion-protection"
+
+	// RegionChangeIgnore ignores changes to the region of an existing Instance.
+	RegionChangeIgnore = "Ignore"
+
+	// RegionChangeMigrate migrates an existing Instance to its new region.
+	RegionChangeMigrate = "Migrate"
+
+	// InstanceStatusRescue is the Instance status of a Linode Instance booted
+	// into Rescue Mode. Linode reports such Instances as running.
+	InstanceStatusRescue = "rescue"
+
+	// InstanceStatusStopped is the Instance status of a Linode Instance that
+	// was stopped by Linode, for example for non-payment. Stopped Instances
+	// are powered off like offline Instances.
+	InstanceStatusStopped = "stopped"
+)
+
+// InstanceDevice is a Linode Instance Disk or Block Storage Volume assigned to a device slot.
+// Only one of DiskRef, DiskID or VolumeID may be set.
+type InstanceDevice struct {
+	// DiskRef references an InstanceDisk in the same namespace
+	// +optional
+	DiskRef *corev1.LocalObjectReference `json:"diskRef,omitempty"`
+
+	// DiskID is the ID of a Linode Instance Disk
+	// +optional
+	DiskID int `json:"diskID,omitempty"`
+
+	// VolumeID is the ID of a Linode Block Storage Volume
+	// +optional
+	VolumeID int `json:"volumeID,omitempty"`
+}
+
+// InstanceDeviceMap assigns Linode Instance Disks and Volumes to the sda through sdh device slots
+type InstanceDeviceMap struct {
+	// +optional
+	SDA *InstanceDevice `json:"sda,omitempty"`
+	// +optional
+	SDB *InstanceDevice `json:"sdb,omitempty"`
+	// +optional
+	SDC *InstanceDevice `json:"sdc,omitempty"`
+	// +optional
+	SDD *InstanceDevice `json:"sdd,omitempty"`
+	// +optional
+	SDE *InstanceDevice `json:"sde,omitempty"`
+	// +optional
+	SDF *InstanceDevice `json:"sdf,omitempty"`
+	// +optional
+	SDG *InstanceDevice `json:"sdg,omitempty"`
+	// +optional
+	SDH *InstanceDevice `json:"sdh,omitempty"`
+}
+
+// InstanceCloneSource identifies the Linode Instance that an Instance is cloned from.
+// Only one of InstanceRef or LinodeID may be set.
+type InstanceCloneSource struct {
+	// InstanceRef references an Instance in the same namespace to clone
+	// +optional
+	InstanceRef *corev1.LocalObjectReference `json:"instanceRef,omitempty"`
+
+	// LinodeID is the ID of an existing Linode Instance to clone
+	// +optional
+	LinodeID int `json:"linodeID,omitempty"`
+
+	// Disks are the IDs of the source Instance Disks to clone.
+	// All Disks and Configs are cloned when neither Disks nor Configs are set.
+	// +optional
+	Disks []int `json:"disks,omitempty"`
+
+	// Configs are the IDs of the source Instance Configs to clone, along with the Disks they use.
+	// All Disks and Configs are cloned when neither Disks nor Configs are set.
+	// +optional
+	Configs []int `json:"configs,omitempty"`
+}
+
+// InstanceRestoreSource identifies the Linode Instance Backup that an Instance
+// is restored from. Only one of SnapshotRef or BackupID may be set.
+type InstanceRestoreSource struct {
+	// SnapshotRef references an InstanceSnapshot in the same namespace to restore
+	// +optional
+	SnapshotRef *corev1.LocalObjectReference `json:"snapshotRef,omitempty"`
+
+	// BackupID is the ID of an existing Linode Instance Backup to restore
+	// +optional
+	BackupID int `json:"backupID,omitempty"`
+}
+
+// InstanceRescueParameters configure how a Linode Instance is booted into Rescue Mode
+type InstanceRescueParameters struct {
+	// Devices are the Disks and Volumes made available to the Rescue Mode environment.
+	// The Linode API assigns the Instance Disks in order when this is not set.
+	// +optional
+	Devices *InstanceDeviceMap `json:"devices,omitempty"`
+}
+
 // +kubebuilder:validation:Required
 
 type InstanceParameters struct {
@@ -41,23 +147,72 @@ type InstanceParameters struct {
 	// +optional
 	Label string `json:"label,omitempty"`
 
-	// Image is the disk image to be applied to the first instance disk
+	// Image is the disk image to be applied to the first instance disk.
+	// Instances without an Image are created without Disks or Configs, which
+	// may then be defined by InstanceDisk and InstanceConfig resources.
 	// +optional
 	Image string `json:"image,omitempty"`
 
-	// AuthorizedUsers are Linode user accounts whose SSH keys will be authorized to SSH into the instance
+	// ImageRef references an Image in the same namespace to be applied to the
+	// first instance disk, in place of Image. Like Image, it may not be
+	// changed once the Instance is created.
+	// +optional
+	ImageRef *corev1.LocalObjectReference `json:"imageRef,omitempty"`
+
+	// AuthorizedUsers are Linode user accounts whose SSH keys will be authorized to SSH into the instance.
+	// Like SSHKeyRefs, they only apply when the Instance is created from an Image.
 	// +optional
 	AuthorizedUsers []string `json:"authorizedUsers,omitempty"`
 
+	// SSHKeyRefs reference SSHKeys in the same namespace whose public keys will be authorized to SSH into the instance.
+	// Keys are only deployed when the Instance is created from an Image. Changing SSHKeyRefs or the referenced SSHKeys
+	// later does not change the keys authorized on an existing Instance.
+	// +optional
+	SSHKeyRefs []corev1.LocalObjectReference `json:"sshKeyRefs,omitempty"`
+
 	// Region defines the geographic location of a Linode Instance
 	Region string `json:"region"`
 
-	// Type is the Linode Instance Type which represents the cost, processor, memory, transfer, and storage profile of the Instance
+	// RegionChangePolicy determines how a change to the region of an existing Instance is handled.
+	// Instances are migrated to their new region with Migrate, and otherwise left where they are.
+	// +kubebuilder:validation:Enum=Ignore;Migrate
+	// +optional
+	RegionChangePolicy string `json:"regionChangePolicy,omitempty"`
+
+	// CloneFrom creates the Instance as a clone of another Linode Instance
+	// +optional
+	CloneFrom *InstanceCloneSource `json:"cloneFrom,omitempty"`
+
+	// RestoreFrom creates the Instance from a Linode Instance Backup, in place of an Image.
+	// The Backup is only restored when the Instance is created.
+	// +optional
+	RestoreFrom *InstanceRestoreSource `json:"restoreFrom,omitempty"`
+
+	// Type is the Linode Instance Type which represents the cost, processor, memory, transfer, and storage profile of the Instance. Changing the Type resizes the Instance.
 	Type string `json:"type"`
 
-	// Status is the current activity status of a Linode Instance
-	// +kubebuilder:validation:Enum=offline;running
+	// Status is the current activity status of a Linode Instance.
+	// Instances with a status of rescue are booted into Rescue Mode and are
+	// rebooted normally when the status returns to running. Instances without
+	// a Config to boot remain offline until an InstanceConfig is added.
+	// +kubebuilder:validation:Enum=offline;running;rescue
 	Status string `json:"status,omitempty"`
+
+	// Rescue configures Rescue Mode for Instances with a status of rescue
+	// +optional
+	Rescue *InstanceRescueParameters `json:"rescue,omitempty"`
+
+	// BootConfigID is the Linode Instance Config used when booting or rebooting the Instance.
+	// The Linode API chooses the last booted Config when this is not set.
+	// +optional
+	BootConfigID int `json:"bootConfigID,omitempty"`
+
+	// DeletionProtection prevents the Linode Instance from being deleted, even
+	// when the Instance is deleted with a reclaim policy of Delete. Deletion
+	// proceeds once it is set to false. The deletion-protection annotation
+	// overrides it.
+	// +optional
+	DeletionProtection bool `json:"deletionProtection,omitempty"`
 }
 
 // InstanceSpec defines the desired state of Instance
@@ -66,6 +221,13 @@ type InstanceSpec struct {
 	// Important: Run "make" to regenerate code after modifying this file
 	runtimev1alpha1.ResourceSpec `json:",inline"`
 	InstanceParameters           `json:",inline"`
+
+	// ManagementPolicy determines whether the Linode Instance is created, updated
+	// and deleted, or only observed. Defaults to Full. Instances that are only
+	// observed find an existing Linode Instance by their label.
+	// +kubebuilder:validation:Enum=Full;ObserveOnly;NoDelete
+	// +optional
+	ManagementPolicy string `json:"managementPolicy,omitempty"`
 }
 
 // InstanceStatus defines the observed state of Instance
@@ -78,7 +240,7 @@ type InstanceStatus struct {
 	// +optional
 	Id int `json:"id,omitempty"`
 
-	// Status is the current activity status of a Linode Instance
+	// Status is the current activity status of a Linode Instance, or rescue for Instances in Rescue Mode
 	Status string `json:"status"`
 
 	// Label is the unique mutable name of a Linode Instance
@@ -90,6 +252,11 @@ type InstanceStatus struct {
 	// Type is the Linode Instance Type which represents the cost, processor, memory, transfer, and storage profile of the Instance
 	Type string `json:"type"`
 
+	// EstimatedMonthlyCost is the estimated monthly cost of the Linode Instance Type in US dollars, such as 10.00.
+	// Backups are estimated by the InstanceBackupPolicy of the Instance.
+	// +optional
+	EstimatedMonthlyCost string `json:"estimatedMonthlyCost,omitempty"`
+
 	// IPv6 is the public IPv6 address of a Linode Instance
 	// +optional
 	IPv6 string `json:"ipv6,omitempty"`
@@ -101,10 +268,16 @@ type InstanceStatus struct {
 	// Image is the image detected on a Linode Instance disk
 	// +optional
 	Image string `json:"image,omitempty"`
+
+	// LastRebootRequestedAt is the last reboot-requested-at annotation value handled by the controller
+	// +optional
+	LastRebootRequestedAt string `json:"lastRebootRequestedAt,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status",description="Whether this Linode Instance has reached its desired status"
+// +kubebuilder:printcolumn:name="COST",type="string",JSONPath=".status.estimatedMonthlyCost",description="Estimated monthly cost of this Linode Instance in US dollars"
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:printcolumn:name="LABEL",type="string",JSONPath=".status.label",description="Unique label associated with this Linode Instance",priority=1
 // +kubebuilder:printcolumn:name="REGION",type="string",JSONPath=".status.region",description="Region where this Linode Instance is deployed",priority=1
@@ -137,6 +310,23 @@ func (s *Instance) SetStatus(status *InstanceStatus) {
 	s.Status = *status
 }
 
+// DeletionProtected returns true if the Linode Instance may not be deleted,
+// according to the deletion-protection annotation of the Instance or, when
+// it is not set, its deletionProtection.
+func (a *Instance) DeletionProtected() bool {
+	if v, ok := a.GetAnnotations()[AnnotationDeletionProtection]; ok {
+		if p, err := strconv.ParseBool(v); err == nil {
+			return p
+		}
+	}
+	return a.Spec.DeletionProtection
+}
+
+// GetProviderReference of this Instance.
+func (a *Instance) GetProviderReference() *corev1.ObjectReference {
+	return a.Spec.ProviderReference
+}
+
 // SetBindingPhase of this Instance.
 func (a *Instance) SetBindingPhase(p runtimev1alpha1.BindingPhase) {
 	a.Status.SetBindingPhase(p)
@@ -152,6 +342,11 @@ func (a *Instance) SetConditions(c ...runtimev1alpha1.Condition) {
 	a.Status.SetConditions(c...)
 }
 
+// GetCondition of this Instance.
+func (a *Instance) GetCondition(ct runtimev1alpha1.ConditionType) runtimev1alpha1.Condition {
+	return GetCondition(a.Status.ConditionedStatus, ct)
+}
+
 // SetClaimReference of this Instance.
 func (a *Instance) SetClaimReference(r *corev1.ObjectReference) {
 	a.Spec.ClaimReference = r
@@ -182,6 +377,11 @@ func (a *Instance) GetWriteConnectionSecretToReference() corev1.LocalObjectRefer
 	return a.Spec.WriteConnectionSecretToReference
 }
 
+// GetManagementPolicy of this Instance.
+func (a *Instance) GetManagementPolicy() string {
+	return a.Spec.ManagementPolicy
+}
+
 // GetReclaimPolicy of this Instance.
 func (a *Instance) GetReclaimPolicy() runtimev1alpha1.ReclaimPolicy {
 	return a.Spec.ReclaimPolicy
@@ -0,0 +1,154 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// +kubebuilder:webhook:path=/mutate-linode-stack-crossplane-io-v1alpha1-instance,mutating=true,failurePolicy=fail,groups=linode.stack.crossplane.io,resources=instances,verbs=create;update,versions=v1alpha1,name=minstance.linode.stack.crossplane.io
+// +kubebuilder:webhook:path=/validate-linode-stack-crossplane-io-v1alpha1-instance,mutating=false,failurePolicy=fail,groups=linode.stack.crossplane.io,resources=instances,verbs=create;update,versions=v1alpha1,name=vinstance.linode.stack.crossplane.io
+
+var _ webhook.Defaulter = &Instance{}
+var _ webhook.Validator = &Instance{}
+
+// Linode Instance labels are 3 to 64 characters long, begin and end with an
+// alphanumeric character, and may not contain two dashes, underscores or
+// periods in a row.
+const (
+	instanceLabelMinLength = 3
+	instanceLabelMaxLength = 64
+)
+
+var (
+	instanceLabelPattern = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9_.-]*[A-Za-z0-9])?$`)
+	regionPattern        = regexp.MustCompile(`^[a-z]{2}-[a-z]+(-[0-9]+)?$`)
+	instanceTypePattern  = regexp.MustCompile(`^g[0-9]+(-[a-z0-9]+)+$`)
+	imagePattern         = regexp.MustCompile(`^(linode|private)/[A-Za-z0-9._-]+$`)
+)
+
+// SetupWebhookWithManager registers the defaulting and validating webhooks
+// of the Instance with the supplied manager.
+func (r *Instance) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(r).Complete()
+}
+
+// Default the label of an Instance to its name, when its name is a valid
+// Linode Instance label, and its status to running.
+func (r *Instance) Default() {
+	if r.Spec.Label == "" && len(validateInstanceLabel(r.GetName(), field.NewPath("metadata", "name"))) == 0 {
+		r.Spec.Label = r.GetName()
+	}
+	if r.Spec.Status == "" {
+		r.Spec.Status = "running"
+	}
+}
+
+// ValidateCreate validates the parameters of a new Instance.
+func (r *Instance) ValidateCreate() error {
+	return r.invalid(r.validateParameters())
+}
+
+// ValidateUpdate validates the parameters of an updated Instance, and that
+// parameters that cannot be changed once the Linode Instance exists are not.
+func (r *Instance) ValidateUpdate(old runtime.Object) error {
+	errs := r.validateParameters()
+	if o, ok := old.(*Instance); ok {
+		errs = append(errs, r.validateImmutable(o)...)
+	}
+	return r.invalid(errs)
+}
+
+// ValidateDelete allows every Instance to be deleted.
+func (r *Instance) ValidateDelete() error {
+	return nil
+}
+
+func (r *Instance) invalid(errs field.ErrorList) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(InstanceGroupVersionKind.GroupKind(), r.GetName(), errs)
+}
+
+func (r *Instance) validateParameters() field.ErrorList {
+	p := field.NewPath("spec")
+	errs := field.ErrorList{}
+
+	if r.Spec.Label != "" {
+		errs = append(errs, validateInstanceLabel(r.Spec.Label, p.Child("label"))...)
+	}
+	if !regionPattern.MatchString(r.Spec.Region) {
+		errs = append(errs, field.Invalid(p.Child("region"), r.Spec.Region, "must be a Linode region ID, such as us-east"))
+	}
+	if !instanceTypePattern.MatchString(r.Spec.Type) {
+		errs = append(errs, field.Invalid(p.Child("type"), r.Spec.Type, "must be a Linode Instance Type ID, such as g6-standard-1"))
+	}
+	if r.Spec.Image != "" && !imagePattern.MatchString(r.Spec.Image) {
+		errs = append(errs, field.Invalid(p.Child("image"), r.Spec.Image, "must be a Linode Image ID, such as linode/debian9 or private/12345"))
+	}
+	if r.Spec.Image != "" && r.Spec.ImageRef != nil {
+		errs = append(errs, field.Forbidden(p.Child("imageRef"), "may not be set together with image"))
+	}
+	return errs
+}
+
+func (r *Instance) validateImmutable(old *Instance) field.ErrorList {
+	// Parameters may change freely until the Linode Instance is created.
+	if old.Status.Id == 0 {
+		return nil
+	}
+
+	p := field.NewPath("spec")
+	errs := field.ErrorList{}
+
+	if r.Spec.Region != old.Spec.Region && r.Spec.RegionChangePolicy != RegionChangeMigrate {
+		errs = append(errs, field.Forbidden(p.Child("region"), "may only be changed when regionChangePolicy is Migrate"))
+	}
+	if r.Spec.Image != old.Spec.Image {
+		errs = append(errs, field.Forbidden(p.Child("image"), "may not be changed once the Instance is created"))
+	}
+	if !reflect.DeepEqual(r.Spec.ImageRef, old.Spec.ImageRef) {
+		errs = append(errs, field.Forbidden(p.Child("imageRef"), "may not be changed once the Instance is created"))
+	}
+	return errs
+}
+
+func validateInstanceLabel(label string, p *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+	if len(label) < instanceLabelMinLength || len(label) > instanceLabelMaxLength {
+		errs = append(errs, field.Invalid(p, label, "must be between 3 and 64 characters long"))
+	}
+	if !instanceLabelPattern.MatchString(label) {
+		errs = append(errs, field.Invalid(p, label, "must begin and end with an alphanumeric character and contain only alphanumeric characters, dashes, underscores and periods"))
+	}
+	for _, s := range []string{"--", "__", ".."} {
+		if strings.Contains(label, s) {
+			errs = append(errs, field.Invalid(p, label, "may not contain two dashes, underscores or periods in a row"))
+			break
+		}
+	}
+	return errs
+}
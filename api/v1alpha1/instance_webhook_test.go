@@ -0,0 +1,190 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// invalidFields returns the fields reported by the supplied validation error.
+func invalidFields(t *testing.T, err error) []string {
+	t.Helper()
+	if err == nil {
+		return nil
+	}
+	s, ok := err.(*apierrors.StatusError)
+	if !ok || s.ErrStatus.Details == nil {
+		t.Fatalf("want an Invalid StatusError, got %v", err)
+	}
+	fields := []string{}
+	for _, c := range s.ErrStatus.Details.Causes {
+		fields = append(fields, c.Field)
+	}
+	return fields
+}
+
+func TestInstanceDefault(t *testing.T) {
+	cases := map[string]struct {
+		name       string
+		spec       InstanceParameters
+		wantLabel  string
+		wantStatus string
+	}{
+		"LabelFromName": {
+			name:       "web-1",
+			wantLabel:  "web-1",
+			wantStatus: "running",
+		},
+		"LabelKept": {
+			name:       "web-1",
+			spec:       InstanceParameters{Label: "frontend", Status: "offline"},
+			wantLabel:  "frontend",
+			wantStatus: "offline",
+		},
+		"NameNotALabel": {
+			name:       "web--1",
+			wantStatus: "running",
+		},
+		"NameTooShort": {
+			name:       "db",
+			wantStatus: "running",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := &Instance{ObjectMeta: metav1.ObjectMeta{Name: tc.name}}
+			r.Spec.InstanceParameters = tc.spec
+			r.Default()
+			if r.Spec.Label != tc.wantLabel {
+				t.Errorf("Default(): want label %q, got %q", tc.wantLabel, r.Spec.Label)
+			}
+			if r.Spec.Status != tc.wantStatus {
+				t.Errorf("Default(): want status %q, got %q", tc.wantStatus, r.Spec.Status)
+			}
+		})
+	}
+}
+
+func TestInstanceValidateCreate(t *testing.T) {
+	valid := InstanceParameters{Label: "web-1", Region: "us-east", Type: "g6-standard-1", Image: "linode/debian9"}
+
+	cases := map[string]struct {
+		spec func(p *InstanceParameters)
+		want []string
+	}{
+		"Valid": {
+			spec: func(p *InstanceParameters) {},
+		},
+		"PrivateImage": {
+			spec: func(p *InstanceParameters) { p.Image = "private/12345" },
+		},
+		"NoLabel": {
+			spec: func(p *InstanceParameters) { p.Label = "" },
+		},
+		"LabelTooShort": {
+			spec: func(p *InstanceParameters) { p.Label = "db" },
+			want: []string{"spec.label"},
+		},
+		"LabelRepeatedSeparators": {
+			spec: func(p *InstanceParameters) { p.Label = "web__1" },
+			want: []string{"spec.label"},
+		},
+		"LabelBadCharacters": {
+			spec: func(p *InstanceParameters) { p.Label = "-web 1" },
+			want: []string{"spec.label"},
+		},
+		"InvalidRegionAndType": {
+			spec: func(p *InstanceParameters) { p.Region, p.Type = "US East", "standard" },
+			want: []string{"spec.region", "spec.type"},
+		},
+		"InvalidImage": {
+			spec: func(p *InstanceParameters) { p.Image = "debian9" },
+			want: []string{"spec.image"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := &Instance{ObjectMeta: metav1.ObjectMeta{Name: "web-1"}}
+			r.Spec.InstanceParameters = valid
+			tc.spec(&r.Spec.InstanceParameters)
+			if got := invalidFields(t, r.ValidateCreate()); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("ValidateCreate(): want invalid fields %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestInstanceValidateUpdate(t *testing.T) {
+	old := &Instance{ObjectMeta: metav1.ObjectMeta{Name: "web-1"}}
+	old.Spec.InstanceParameters = InstanceParameters{Label: "web-1", Region: "us-east", Type: "g6-standard-1", Image: "linode/debian9"}
+
+	cases := map[string]struct {
+		id   int
+		spec func(p *InstanceParameters)
+		want []string
+	}{
+		"NotCreated": {
+			spec: func(p *InstanceParameters) { p.Region, p.Image = "us-west", "linode/debian10" },
+		},
+		"Renamed": {
+			id:   1,
+			spec: func(p *InstanceParameters) { p.Label = "web-2" },
+		},
+		"Resized": {
+			id:   1,
+			spec: func(p *InstanceParameters) { p.Type = "g6-standard-2" },
+		},
+		"RegionChanged": {
+			id:   1,
+			spec: func(p *InstanceParameters) { p.Region = "us-west" },
+			want: []string{"spec.region"},
+		},
+		"RegionMigrated": {
+			id:   1,
+			spec: func(p *InstanceParameters) { p.Region, p.RegionChangePolicy = "us-west", RegionChangeMigrate },
+		},
+		"ImageChanged": {
+			id:   1,
+			spec: func(p *InstanceParameters) { p.Image = "linode/debian10" },
+			want: []string{"spec.image"},
+		},
+		"ImageRefChanged": {
+			id:   1,
+			spec: func(p *InstanceParameters) { p.Image, p.ImageRef = "", &corev1.LocalObjectReference{Name: "custom"} },
+			want: []string{"spec.image", "spec.imageRef"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			o := old.DeepCopy()
+			o.Status.Id = tc.id
+			r := o.DeepCopy()
+			tc.spec(&r.Spec.InstanceParameters)
+			if got := invalidFields(t, r.ValidateUpdate(o)); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("ValidateUpdate(...): want invalid fields %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,202 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+)
+
+var (
+	InstanceBackupPolicyKind             = reflect.TypeOf(InstanceBackupPolicy{}).Name()
+	InstanceBackupPolicyKindAPIVersion   = InstanceBackupPolicyKind + "." + GroupVersion.String()
+	InstanceBackupPolicyGroupVersionKind = GroupVersion.WithKind(InstanceBackupPolicyKind)
+)
+
+// InstanceBackupSchedule defines when a Linode Instance is backed up
+type InstanceBackupSchedule struct {
+	// Day is the day of the week that weekly Backups are taken. Linode chooses the day when this is "Scheduling".
+	// +kubebuilder:validation:Enum=Scheduling;Sunday;Monday;Tuesday;Wednesday;Thursday;Friday;Saturday
+	// +optional
+	Day string `json:"day,omitempty"`
+
+	// Window is the two hour window, in UTC, that daily Backups are taken, such as "W0" for 00:00-02:00.
+	// Linode chooses the window when this is "Scheduling".
+	// +kubebuilder:validation:Enum=Scheduling;W0;W2;W4;W6;W8;W10;W12;W14;W16;W18;W20;W22
+	// +optional
+	Window string `json:"window,omitempty"`
+}
+
+// InstanceBackupPolicyParameters define the desired Backup policy of a Linode
+// Instance. Backups are enabled for the Instance while the InstanceBackupPolicy
+// exists, and cancelled when it is deleted.
+type InstanceBackupPolicyParameters struct {
+	// InstanceRef references the Instance in the same namespace that this policy applies to
+	// +optional
+	InstanceRef *corev1.LocalObjectReference `json:"instanceRef,omitempty"`
+
+	// InstanceID is the ID of the Linode Instance that this policy applies to, when InstanceRef is not set
+	// +optional
+	InstanceID int `json:"instanceID,omitempty"`
+
+	// Schedule is when the Instance is backed up. Linode chooses the schedule when this is not set.
+	// +optional
+	Schedule *InstanceBackupSchedule `json:"schedule,omitempty"`
+}
+
+// InstanceBackupPolicySpec defines the desired state of InstanceBackupPolicy
+type InstanceBackupPolicySpec struct {
+	runtimev1alpha1.ResourceSpec   `json:",inline"`
+	InstanceBackupPolicyParameters `json:",inline"`
+
+	// ManagementPolicy determines whether Backups of the Linode Instance are
+	// cancelled when the InstanceBackupPolicy is deleted. Defaults to Full.
+	// InstanceBackupPolicies may not be only observed.
+	// +kubebuilder:validation:Enum=Full;NoDelete
+	// +optional
+	ManagementPolicy string `json:"managementPolicy,omitempty"`
+}
+
+// InstanceBackupPolicyStatus defines the observed state of InstanceBackupPolicy
+type InstanceBackupPolicyStatus struct {
+	runtimev1alpha1.ResourceStatus `json:",inline"`
+
+	// InstanceID is the ID of the Linode Instance that this policy applies to
+	// +optional
+	InstanceID int `json:"instanceID,omitempty"`
+
+	// Enabled is true when Backups are enabled for the Linode Instance
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Day is the day of the week that weekly Backups are taken
+	// +optional
+	Day string `json:"day,omitempty"`
+
+	// Window is the two hour window that daily Backups are taken
+	// +optional
+	Window string `json:"window,omitempty"`
+
+	// EstimatedMonthlyCost is the estimated monthly cost of the Backups in US dollars, such as 2.50
+	// +optional
+	EstimatedMonthlyCost string `json:"estimatedMonthlyCost,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="COST",type="string",JSONPath=".status.estimatedMonthlyCost",description="Estimated monthly cost of the Backups in US dollars"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:printcolumn:name="INSTANCE",type="integer",JSONPath=".status.instanceID",description="ID of the Linode Instance that is backed up",priority=1
+// +kubebuilder:printcolumn:name="DAY",type="string",JSONPath=".status.day",description="Day of the week that weekly Backups are taken",priority=1
+// +kubebuilder:printcolumn:name="WINDOW",type="string",JSONPath=".status.window",description="Window that daily Backups are taken",priority=1
+
+// InstanceBackupPolicy is the Schema for the instancebackuppolicies API
+type InstanceBackupPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec InstanceBackupPolicySpec `json:"spec,omitempty"`
+	// +optional
+	Status InstanceBackupPolicyStatus `json:"status,omitempty"`
+}
+
+// GetProviderReference of this InstanceBackupPolicy.
+func (a *InstanceBackupPolicy) GetProviderReference() *corev1.ObjectReference {
+	return a.Spec.ProviderReference
+}
+
+// SetBindingPhase of this InstanceBackupPolicy.
+func (a *InstanceBackupPolicy) SetBindingPhase(p runtimev1alpha1.BindingPhase) {
+	a.Status.SetBindingPhase(p)
+}
+
+// GetBindingPhase of this InstanceBackupPolicy.
+func (a *InstanceBackupPolicy) GetBindingPhase() runtimev1alpha1.BindingPhase {
+	return a.Status.GetBindingPhase()
+}
+
+// SetConditions of this InstanceBackupPolicy.
+func (a *InstanceBackupPolicy) SetConditions(c ...runtimev1alpha1.Condition) {
+	a.Status.SetConditions(c...)
+}
+
+// GetCondition of this InstanceBackupPolicy.
+func (a *InstanceBackupPolicy) GetCondition(ct runtimev1alpha1.ConditionType) runtimev1alpha1.Condition {
+	return GetCondition(a.Status.ConditionedStatus, ct)
+}
+
+// SetClaimReference of this InstanceBackupPolicy.
+func (a *InstanceBackupPolicy) SetClaimReference(r *corev1.ObjectReference) {
+	a.Spec.ClaimReference = r
+}
+
+// GetClaimReference of this InstanceBackupPolicy.
+func (a *InstanceBackupPolicy) GetClaimReference() *corev1.ObjectReference {
+	return a.Spec.ClaimReference
+}
+
+// SetNonPortableClassReference of this InstanceBackupPolicy.
+func (a *InstanceBackupPolicy) SetNonPortableClassReference(r *corev1.ObjectReference) {
+	a.Spec.NonPortableClassReference = r
+}
+
+// GetNonPortableClassReference of this InstanceBackupPolicy.
+func (a *InstanceBackupPolicy) GetNonPortableClassReference() *corev1.ObjectReference {
+	return a.Spec.NonPortableClassReference
+}
+
+// SetWriteConnectionSecretToReference of this InstanceBackupPolicy.
+func (a *InstanceBackupPolicy) SetWriteConnectionSecretToReference(r corev1.LocalObjectReference) {
+	a.Spec.WriteConnectionSecretToReference = r
+}
+
+// GetWriteConnectionSecretToReference of this InstanceBackupPolicy.
+func (a *InstanceBackupPolicy) GetWriteConnectionSecretToReference() corev1.LocalObjectReference {
+	return a.Spec.WriteConnectionSecretToReference
+}
+
+// GetManagementPolicy of this InstanceBackupPolicy.
+func (a *InstanceBackupPolicy) GetManagementPolicy() string {
+	return a.Spec.ManagementPolicy
+}
+
+// GetReclaimPolicy of this InstanceBackupPolicy.
+func (a *InstanceBackupPolicy) GetReclaimPolicy() runtimev1alpha1.ReclaimPolicy {
+	return a.Spec.ReclaimPolicy
+}
+
+// SetReclaimPolicy of this InstanceBackupPolicy.
+func (a *InstanceBackupPolicy) SetReclaimPolicy(p runtimev1alpha1.ReclaimPolicy) {
+	a.Spec.ReclaimPolicy = p
+}
+
+// +kubebuilder:object:root=true
+
+// InstanceBackupPolicyList contains a list of InstanceBackupPolicy
+type InstanceBackupPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []InstanceBackupPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&InstanceBackupPolicy{}, &InstanceBackupPolicyList{})
+}
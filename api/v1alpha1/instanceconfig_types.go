@@ -0,0 +1,259 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+)
+
+var (
+	InstanceConfigKind             = reflect.TypeOf(InstanceConfig{}).Name()
+	InstanceConfigKindAPIVersion   = InstanceConfigKind + "." + GroupVersion.String()
+	InstanceConfigGroupVersionKind = GroupVersion.WithKind(InstanceConfigKind)
+)
+
+// InstanceConfigHelpers control Linux distribution specific tweaks applied when booting a Config.
+// Helpers that are not set use the Linode API defaults.
+type InstanceConfigHelpers struct {
+	// UpdateDBDisabled disables updatedb cron jobs
+	// +optional
+	UpdateDBDisabled *bool `json:"updatedbDisabled,omitempty"`
+
+	// Distro enables the Distro filesystem helper
+	// +optional
+	Distro *bool `json:"distro,omitempty"`
+
+	// ModulesDep creates a modules dependency file for the Kernel
+	// +optional
+	ModulesDep *bool `json:"modulesDep,omitempty"`
+
+	// Network configures networking automatically at boot
+	// +optional
+	Network *bool `json:"network,omitempty"`
+
+	// DevTmpFsAutomount automatically mounts devtmpfs at boot
+	// +optional
+	DevTmpFsAutomount *bool `json:"devtmpfsAutomount,omitempty"`
+}
+
+// InstanceConfigInterface is a network interface of a Linode Instance Config
+type InstanceConfigInterface struct {
+	// Purpose of the interface
+	// +kubebuilder:validation:Enum=public;vlan
+	Purpose string `json:"purpose"`
+
+	// Label is the name of the VLAN joined by a vlan interface
+	// +optional
+	Label string `json:"label,omitempty"`
+
+	// IPAMAddress is the address of a vlan interface in CIDR notation
+	// +optional
+	IPAMAddress string `json:"ipamAddress,omitempty"`
+}
+
+// InstanceConfigParameters define the desired state of a Linode Instance Config
+type InstanceConfigParameters struct {
+	// InstanceRef references the Instance in the same namespace that this Config belongs to
+	// +optional
+	InstanceRef *corev1.LocalObjectReference `json:"instanceRef,omitempty"`
+
+	// InstanceID is the ID of the Linode Instance that this Config belongs to, when InstanceRef is not set
+	// +optional
+	InstanceID int `json:"instanceID,omitempty"`
+
+	// Label is the name of this Linode Instance Config. The name of the InstanceConfig is used when this is not set.
+	// +optional
+	Label string `json:"label,omitempty"`
+
+	// Comments are optional notes about the Config
+	// +optional
+	Comments string `json:"comments,omitempty"`
+
+	// Kernel is the ID of the Kernel booted by the Config, such as linode/latest-64bit or linode/grub2
+	// +optional
+	Kernel string `json:"kernel,omitempty"`
+
+	// RootDevice is the device the Kernel mounts as the root filesystem, such as /dev/sda
+	// +optional
+	RootDevice string `json:"rootDevice,omitempty"`
+
+	// RunLevel is the run level the Config boots into
+	// +kubebuilder:validation:Enum=default;single;binbash
+	// +optional
+	RunLevel string `json:"runLevel,omitempty"`
+
+	// VirtMode is the virtualization mode the Config boots with
+	// +kubebuilder:validation:Enum=paravirt;fullvirt
+	// +optional
+	VirtMode string `json:"virtMode,omitempty"`
+
+	// MemoryLimit limits the memory in MB available to the Config. The memory is not limited when this is not set.
+	// +optional
+	MemoryLimit int `json:"memoryLimit,omitempty"`
+
+	// Devices are the Disks and Volumes available to the Config
+	// +optional
+	Devices *InstanceDeviceMap `json:"devices,omitempty"`
+
+	// Helpers control Linux distribution specific tweaks applied when booting the Config
+	// +optional
+	Helpers *InstanceConfigHelpers `json:"helpers,omitempty"`
+
+	// Interfaces are the network interfaces of the Config, in order from eth0.
+	// Only a public interface is configured when this is not set.
+	// +optional
+	Interfaces []InstanceConfigInterface `json:"interfaces,omitempty"`
+}
+
+// InstanceConfigSpec defines the desired state of InstanceConfig
+type InstanceConfigSpec struct {
+	runtimev1alpha1.ResourceSpec `json:",inline"`
+	InstanceConfigParameters     `json:",inline"`
+
+	// ManagementPolicy determines whether the Linode Instance Config is
+	// deleted along with the InstanceConfig. Defaults to Full.
+	// InstanceConfigs cannot find an existing Config, so they may not be only
+	// observed.
+	// +kubebuilder:validation:Enum=Full;NoDelete
+	// +optional
+	ManagementPolicy string `json:"managementPolicy,omitempty"`
+}
+
+// InstanceConfigStatus defines the observed state of InstanceConfig
+type InstanceConfigStatus struct {
+	runtimev1alpha1.ResourceStatus `json:",inline"`
+
+	// Id is the unique immutable numeric identifier of a Linode Instance Config
+	// +optional
+	Id int `json:"id,omitempty"`
+
+	// InstanceID is the ID of the Linode Instance that this Config belongs to
+	// +optional
+	InstanceID int `json:"instanceID,omitempty"`
+
+	// Label is the name of a Linode Instance Config
+	// +optional
+	Label string `json:"label,omitempty"`
+
+	// Kernel is the ID of the Kernel booted by a Linode Instance Config
+	// +optional
+	Kernel string `json:"kernel,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:printcolumn:name="LABEL",type="string",JSONPath=".status.label",description="Label of this Linode Instance Config",priority=1
+// +kubebuilder:printcolumn:name="KERNEL",type="string",JSONPath=".status.kernel",description="Kernel booted by this Linode Instance Config",priority=1
+
+// InstanceConfig is the Schema for the instanceconfigs API
+type InstanceConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec InstanceConfigSpec `json:"spec,omitempty"`
+	// +optional
+	Status InstanceConfigStatus `json:"status,omitempty"`
+}
+
+// GetProviderReference of this InstanceConfig.
+func (a *InstanceConfig) GetProviderReference() *corev1.ObjectReference {
+	return a.Spec.ProviderReference
+}
+
+// SetBindingPhase of this InstanceConfig.
+func (a *InstanceConfig) SetBindingPhase(p runtimev1alpha1.BindingPhase) {
+	a.Status.SetBindingPhase(p)
+}
+
+// GetBindingPhase of this InstanceConfig.
+func (a *InstanceConfig) GetBindingPhase() runtimev1alpha1.BindingPhase {
+	return a.Status.GetBindingPhase()
+}
+
+// SetConditions of this InstanceConfig.
+func (a *InstanceConfig) SetConditions(c ...runtimev1alpha1.Condition) {
+	a.Status.SetConditions(c...)
+}
+
+// GetCondition of this InstanceConfig.
+func (a *InstanceConfig) GetCondition(ct runtimev1alpha1.ConditionType) runtimev1alpha1.Condition {
+	return GetCondition(a.Status.ConditionedStatus, ct)
+}
+
+// SetClaimReference of this InstanceConfig.
+func (a *InstanceConfig) SetClaimReference(r *corev1.ObjectReference) {
+	a.Spec.ClaimReference = r
+}
+
+// GetClaimReference of this InstanceConfig.
+func (a *InstanceConfig) GetClaimReference() *corev1.ObjectReference {
+	return a.Spec.ClaimReference
+}
+
+// SetNonPortableClassReference of this InstanceConfig.
+func (a *InstanceConfig) SetNonPortableClassReference(r *corev1.ObjectReference) {
+	a.Spec.NonPortableClassReference = r
+}
+
+// GetNonPortableClassReference of this InstanceConfig.
+func (a *InstanceConfig) GetNonPortableClassReference() *corev1.ObjectReference {
+	return a.Spec.NonPortableClassReference
+}
+
+// SetWriteConnectionSecretToReference of this InstanceConfig.
+func (a *InstanceConfig) SetWriteConnectionSecretToReference(r corev1.LocalObjectReference) {
+	a.Spec.WriteConnectionSecretToReference = r
+}
+
+// GetWriteConnectionSecretToReference of this InstanceConfig.
+func (a *InstanceConfig) GetWriteConnectionSecretToReference() corev1.LocalObjectReference {
+	return a.Spec.WriteConnectionSecretToReference
+}
+
+// GetManagementPolicy of this InstanceConfig.
+func (a *InstanceConfig) GetManagementPolicy() string {
+	return a.Spec.ManagementPolicy
+}
+
+// GetReclaimPolicy of this InstanceConfig.
+func (a *InstanceConfig) GetReclaimPolicy() runtimev1alpha1.ReclaimPolicy {
+	return a.Spec.ReclaimPolicy
+}
+
+// SetReclaimPolicy of this InstanceConfig.
+func (a *InstanceConfig) SetReclaimPolicy(p runtimev1alpha1.ReclaimPolicy) {
+	a.Spec.ReclaimPolicy = p
+}
+
+// +kubebuilder:object:root=true
+
+// InstanceConfigList contains a list of InstanceConfig
+type InstanceConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []InstanceConfig `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&InstanceConfig{}, &InstanceConfigList{})
+}
@@ -0,0 +1,218 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+)
+
+var (
+	InstanceDiskKind             = reflect.TypeOf(InstanceDisk{}).Name()
+	InstanceDiskKindAPIVersion   = InstanceDiskKind + "." + GroupVersion.String()
+	InstanceDiskGroupVersionKind = GroupVersion.WithKind(InstanceDiskKind)
+)
+
+// InstanceDiskParameters define the desired state of a Linode Instance Disk
+type InstanceDiskParameters struct {
+	// InstanceRef references the Instance in the same namespace that this Disk belongs to
+	// +optional
+	InstanceRef *corev1.LocalObjectReference `json:"instanceRef,omitempty"`
+
+	// InstanceID is the ID of the Linode Instance that this Disk belongs to, when InstanceRef is not set
+	// +optional
+	InstanceID int `json:"instanceID,omitempty"`
+
+	// Label is the name of this Linode Instance Disk. The name of the InstanceDisk is used when this is not set.
+	// +optional
+	Label string `json:"label,omitempty"`
+
+	// Size is the size of the Disk in MB. Changing the Size resizes the Disk.
+	// +kubebuilder:validation:Minimum=1
+	Size int `json:"size"`
+
+	// Filesystem is the Disk filesystem
+	// +kubebuilder:validation:Enum=raw;swap;ext3;ext4;initrd
+	// +optional
+	Filesystem string `json:"filesystem,omitempty"`
+
+	// Image is the disk image to be deployed to the Disk when it is created
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// ImageRef references an Image in the same namespace to be deployed to
+	// the Disk when it is created, in place of Image
+	// +optional
+	ImageRef *corev1.LocalObjectReference `json:"imageRef,omitempty"`
+
+	// AuthorizedUsers are Linode user accounts whose SSH keys will be authorized to SSH into a Disk deployed from an Image.
+	// Like SSHKeyRefs, they only apply when the Disk is created.
+	// +optional
+	AuthorizedUsers []string `json:"authorizedUsers,omitempty"`
+
+	// SSHKeyRefs reference SSHKeys in the same namespace whose public keys will be authorized to SSH into a Disk deployed from an Image.
+	// Keys are only deployed when the Disk is created. Changing SSHKeyRefs or the referenced SSHKeys later does not
+	// change the keys authorized on an existing Disk.
+	// +optional
+	SSHKeyRefs []corev1.LocalObjectReference `json:"sshKeyRefs,omitempty"`
+}
+
+// InstanceDiskSpec defines the desired state of InstanceDisk
+type InstanceDiskSpec struct {
+	runtimev1alpha1.ResourceSpec `json:",inline"`
+	InstanceDiskParameters       `json:",inline"`
+
+	// ManagementPolicy determines whether the Linode Instance Disk is deleted
+	// along with the InstanceDisk. Defaults to Full. InstanceDisks cannot
+	// find an existing Disk, so they may not be only observed.
+	// +kubebuilder:validation:Enum=Full;NoDelete
+	// +optional
+	ManagementPolicy string `json:"managementPolicy,omitempty"`
+}
+
+// InstanceDiskStatus defines the observed state of InstanceDisk
+type InstanceDiskStatus struct {
+	runtimev1alpha1.ResourceStatus `json:",inline"`
+
+	// Id is the unique immutable numeric identifier of a Linode Instance Disk
+	// +optional
+	Id int `json:"id,omitempty"`
+
+	// InstanceID is the ID of the Linode Instance that this Disk belongs to
+	// +optional
+	InstanceID int `json:"instanceID,omitempty"`
+
+	// Status is the current status of a Linode Instance Disk
+	// +optional
+	Status string `json:"status,omitempty"`
+
+	// Label is the name of a Linode Instance Disk
+	// +optional
+	Label string `json:"label,omitempty"`
+
+	// Size is the size of a Linode Instance Disk in MB
+	// +optional
+	Size int `json:"size,omitempty"`
+
+	// Filesystem is the filesystem of a Linode Instance Disk
+	// +optional
+	Filesystem string `json:"filesystem,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:printcolumn:name="LABEL",type="string",JSONPath=".status.label",description="Label of this Linode Instance Disk",priority=1
+// +kubebuilder:printcolumn:name="SIZE",type="integer",JSONPath=".status.size",description="Size of this Linode Instance Disk in MB",priority=1
+// +kubebuilder:printcolumn:name="STATUS",type="string",JSONPath=".status.status",description="Status of this Linode Instance Disk",priority=1
+
+// InstanceDisk is the Schema for the instancedisks API
+type InstanceDisk struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec InstanceDiskSpec `json:"spec,omitempty"`
+	// +optional
+	Status InstanceDiskStatus `json:"status,omitempty"`
+}
+
+// GetProviderReference of this InstanceDisk.
+func (a *InstanceDisk) GetProviderReference() *corev1.ObjectReference {
+	return a.Spec.ProviderReference
+}
+
+// SetBindingPhase of this InstanceDisk.
+func (a *InstanceDisk) SetBindingPhase(p runtimev1alpha1.BindingPhase) {
+	a.Status.SetBindingPhase(p)
+}
+
+// GetBindingPhase of this InstanceDisk.
+func (a *InstanceDisk) GetBindingPhase() runtimev1alpha1.BindingPhase {
+	return a.Status.GetBindingPhase()
+}
+
+// SetConditions of this InstanceDisk.
+func (a *InstanceDisk) SetConditions(c ...runtimev1alpha1.Condition) {
+	a.Status.SetConditions(c...)
+}
+
+// GetCondition of this InstanceDisk.
+func (a *InstanceDisk) GetCondition(ct runtimev1alpha1.ConditionType) runtimev1alpha1.Condition {
+	return GetCondition(a.Status.ConditionedStatus, ct)
+}
+
+// SetClaimReference of this InstanceDisk.
+func (a *InstanceDisk) SetClaimReference(r *corev1.ObjectReference) {
+	a.Spec.ClaimReference = r
+}
+
+// GetClaimReference of this InstanceDisk.
+func (a *InstanceDisk) GetClaimReference() *corev1.ObjectReference {
+	return a.Spec.ClaimReference
+}
+
+// SetNonPortableClassReference of this InstanceDisk.
+func (a *InstanceDisk) SetNonPortableClassReference(r *corev1.ObjectReference) {
+	a.Spec.NonPortableClassReference = r
+}
+
+// GetNonPortableClassReference of this InstanceDisk.
+func (a *InstanceDisk) GetNonPortableClassReference() *corev1.ObjectReference {
+	return a.Spec.NonPortableClassReference
+}
+
+// SetWriteConnectionSecretToReference of this InstanceDisk.
+func (a *InstanceDisk) SetWriteConnectionSecretToReference(r corev1.LocalObjectReference) {
+	a.Spec.WriteConnectionSecretToReference = r
+}
+
+// GetWriteConnectionSecretToReference of this InstanceDisk.
+func (a *InstanceDisk) GetWriteConnectionSecretToReference() corev1.LocalObjectReference {
+	return a.Spec.WriteConnectionSecretToReference
+}
+
+// GetManagementPolicy of this InstanceDisk.
+func (a *InstanceDisk) GetManagementPolicy() string {
+	return a.Spec.ManagementPolicy
+}
+
+// GetReclaimPolicy of this InstanceDisk.
+func (a *InstanceDisk) GetReclaimPolicy() runtimev1alpha1.ReclaimPolicy {
+	return a.Spec.ReclaimPolicy
+}
+
+// SetReclaimPolicy of this InstanceDisk.
+func (a *InstanceDisk) SetReclaimPolicy(p runtimev1alpha1.ReclaimPolicy) {
+	a.Spec.ReclaimPolicy = p
+}
+
+// +kubebuilder:object:root=true
+
+// InstanceDiskList contains a list of InstanceDisk
+type InstanceDiskList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []InstanceDisk `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&InstanceDisk{}, &InstanceDiskList{})
+}
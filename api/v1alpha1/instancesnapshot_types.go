@@ -0,0 +1,183 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+)
+
+var (
+	InstanceSnapshotKind             = reflect.TypeOf(InstanceSnapshot{}).Name()
+	InstanceSnapshotKindAPIVersion   = InstanceSnapshotKind + "." + GroupVersion.String()
+	InstanceSnapshotGroupVersionKind = GroupVersion.WithKind(InstanceSnapshotKind)
+)
+
+// InstanceSnapshotParameters define the desired state of a manual Linode
+// Instance Snapshot. Backups must be enabled for the Instance, and each
+// Instance holds a single manual Snapshot, which is replaced by the next one
+// taken.
+type InstanceSnapshotParameters struct {
+	// InstanceRef references the Instance in the same namespace that is snapshotted
+	// +optional
+	InstanceRef *corev1.LocalObjectReference `json:"instanceRef,omitempty"`
+
+	// InstanceID is the ID of the Linode Instance that is snapshotted, when InstanceRef is not set
+	// +optional
+	InstanceID int `json:"instanceID,omitempty"`
+
+	// Label is the name of this Linode Instance Snapshot. The name of the InstanceSnapshot is used when this is not set.
+	// +optional
+	Label string `json:"label,omitempty"`
+}
+
+// InstanceSnapshotSpec defines the desired state of InstanceSnapshot
+type InstanceSnapshotSpec struct {
+	runtimev1alpha1.ResourceSpec `json:",inline"`
+	InstanceSnapshotParameters   `json:",inline"`
+
+	// ManagementPolicy of the InstanceSnapshot. Defaults to Full. Snapshots
+	// are never deleted, and InstanceSnapshots cannot find an existing
+	// Snapshot, so they may not be only observed.
+	// +kubebuilder:validation:Enum=Full;NoDelete
+	// +optional
+	ManagementPolicy string `json:"managementPolicy,omitempty"`
+}
+
+// InstanceSnapshotStatus defines the observed state of InstanceSnapshot
+type InstanceSnapshotStatus struct {
+	runtimev1alpha1.ResourceStatus `json:",inline"`
+
+	// Id is the unique immutable numeric identifier of a Linode Instance Backup
+	// +optional
+	Id int `json:"id,omitempty"`
+
+	// InstanceID is the ID of the Linode Instance that is snapshotted
+	// +optional
+	InstanceID int `json:"instanceID,omitempty"`
+
+	// Status is the current status of a Linode Instance Snapshot
+	// +optional
+	Status string `json:"status,omitempty"`
+
+	// Label is the name of a Linode Instance Snapshot
+	// +optional
+	Label string `json:"label,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:printcolumn:name="LABEL",type="string",JSONPath=".status.label",description="Label of this Linode Instance Snapshot",priority=1
+// +kubebuilder:printcolumn:name="STATUS",type="string",JSONPath=".status.status",description="Status of this Linode Instance Snapshot",priority=1
+
+// InstanceSnapshot is the Schema for the instancesnapshots API
+type InstanceSnapshot struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec InstanceSnapshotSpec `json:"spec,omitempty"`
+	// +optional
+	Status InstanceSnapshotStatus `json:"status,omitempty"`
+}
+
+// GetProviderReference of this InstanceSnapshot.
+func (a *InstanceSnapshot) GetProviderReference() *corev1.ObjectReference {
+	return a.Spec.ProviderReference
+}
+
+// SetBindingPhase of this InstanceSnapshot.
+func (a *InstanceSnapshot) SetBindingPhase(p runtimev1alpha1.BindingPhase) {
+	a.Status.SetBindingPhase(p)
+}
+
+// GetBindingPhase of this InstanceSnapshot.
+func (a *InstanceSnapshot) GetBindingPhase() runtimev1alpha1.BindingPhase {
+	return a.Status.GetBindingPhase()
+}
+
+// SetConditions of this InstanceSnapshot.
+func (a *InstanceSnapshot) SetConditions(c ...runtimev1alpha1.Condition) {
+	a.Status.SetConditions(c...)
+}
+
+// GetCondition of this InstanceSnapshot.
+func (a *InstanceSnapshot) GetCondition(ct runtimev1alpha1.ConditionType) runtimev1alpha1.Condition {
+	return GetCondition(a.Status.ConditionedStatus, ct)
+}
+
+// SetClaimReference of this InstanceSnapshot.
+func (a *InstanceSnapshot) SetClaimReference(r *corev1.ObjectReference) {
+	a.Spec.ClaimReference = r
+}
+
+// GetClaimReference of this InstanceSnapshot.
+func (a *InstanceSnapshot) GetClaimReference() *corev1.ObjectReference {
+	return a.Spec.ClaimReference
+}
+
+// SetNonPortableClassReference of this InstanceSnapshot.
+func (a *InstanceSnapshot) SetNonPortableClassReference(r *corev1.ObjectReference) {
+	a.Spec.NonPortableClassReference = r
+}
+
+// GetNonPortableClassReference of this InstanceSnapshot.
+func (a *InstanceSnapshot) GetNonPortableClassReference() *corev1.ObjectReference {
+	return a.Spec.NonPortableClassReference
+}
+
+// SetWriteConnectionSecretToReference of this InstanceSnapshot.
+func (a *InstanceSnapshot) SetWriteConnectionSecretToReference(r corev1.LocalObjectReference) {
+	a.Spec.WriteConnectionSecretToReference = r
+}
+
+// GetWriteConnectionSecretToReference of this InstanceSnapshot.
+func (a *InstanceSnapshot) GetWriteConnectionSecretToReference() corev1.LocalObjectReference {
+	return a.Spec.WriteConnectionSecretToReference
+}
+
+// GetManagementPolicy of this InstanceSnapshot.
+func (a *InstanceSnapshot) GetManagementPolicy() string {
+	return a.Spec.ManagementPolicy
+}
+
+// GetReclaimPolicy of this InstanceSnapshot.
+func (a *InstanceSnapshot) GetReclaimPolicy() runtimev1alpha1.ReclaimPolicy {
+	return a.Spec.ReclaimPolicy
+}
+
+// SetReclaimPolicy of this InstanceSnapshot.
+func (a *InstanceSnapshot) SetReclaimPolicy(p runtimev1alpha1.ReclaimPolicy) {
+	a.Spec.ReclaimPolicy = p
+}
+
+// +kubebuilder:object:root=true
+
+// InstanceSnapshotList contains a list of InstanceSnapshot
+type InstanceSnapshotList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []InstanceSnapshot `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&InstanceSnapshot{}, &InstanceSnapshotList{})
+}
@@ -0,0 +1,33 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// Management policies of managed resources.
+const (
+	// ManagementPolicyFull creates, updates and deletes the external resource.
+	// It is the default.
+	ManagementPolicyFull = "Full"
+
+	// ManagementPolicyObserveOnly only observes an existing external
+	// resource, populating the status and connection details of the managed
+	// resource. The external resource is never created, updated or deleted.
+	ManagementPolicyObserveOnly = "ObserveOnly"
+
+	// ManagementPolicyNoDelete creates and updates the external resource, but
+	// leaves it in place when the managed resource is deleted.
+	ManagementPolicyNoDelete = "NoDelete"
+)
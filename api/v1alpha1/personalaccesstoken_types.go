@@ -0,0 +1,199 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+)
+
+var (
+	PersonalAccessTokenKind             = reflect.TypeOf(PersonalAccessToken{}).Name()
+	PersonalAccessTokenKindAPIVersion   = PersonalAccessTokenKind + "." + GroupVersion.String()
+	PersonalAccessTokenGroupVersionKind = GroupVersion.WithKind(PersonalAccessTokenKind)
+)
+
+// PersonalAccessTokenParameters define the desired state of a Linode Personal
+// Access Token of the Linode user whose credentials the Provider holds
+type PersonalAccessTokenParameters struct {
+	// Label is the name of this Linode Personal Access Token. The name of the PersonalAccessToken is used when this is not set.
+	// +optional
+	Label string `json:"label,omitempty"`
+
+	// Scopes are the space separated OAuth scopes of the token, such as "linodes:read_write domains:read_only", or "*" for full access.
+	// Changing the Scopes replaces the token.
+	Scopes string `json:"scopes"`
+
+	// ExpiresIn is how long the token is valid for after it is created, such as "720h".
+	// The token never expires when this is not set. Changing ExpiresIn replaces the token.
+	// +optional
+	ExpiresIn *metav1.Duration `json:"expiresIn,omitempty"`
+
+	// RotateBefore is how long before it expires that the token is replaced by a new one.
+	// Defaults to a fifth of ExpiresIn.
+	// +optional
+	RotateBefore *metav1.Duration `json:"rotateBefore,omitempty"`
+}
+
+// PersonalAccessTokenSpec defines the desired state of PersonalAccessToken
+type PersonalAccessTokenSpec struct {
+	runtimev1alpha1.ResourceSpec  `json:",inline"`
+	PersonalAccessTokenParameters `json:",inline"`
+
+	// ManagementPolicy determines whether the Linode Personal Access Token is
+	// revoked when the PersonalAccessToken is deleted. Defaults to Full. The
+	// secret of an existing token cannot be read, so PersonalAccessTokens may
+	// not be only observed.
+	// +kubebuilder:validation:Enum=Full;NoDelete
+	// +optional
+	ManagementPolicy string `json:"managementPolicy,omitempty"`
+}
+
+// PersonalAccessTokenStatus defines the observed state of PersonalAccessToken
+type PersonalAccessTokenStatus struct {
+	runtimev1alpha1.ResourceStatus `json:",inline"`
+
+	// Id is the unique immutable numeric identifier of a Linode Personal Access Token
+	// +optional
+	Id int `json:"id,omitempty"`
+
+	// Label is the name of a Linode Personal Access Token
+	// +optional
+	Label string `json:"label,omitempty"`
+
+	// Scopes are the OAuth scopes of a Linode Personal Access Token
+	// +optional
+	Scopes string `json:"scopes,omitempty"`
+
+	// Created is when a Linode Personal Access Token was created
+	// +optional
+	Created *metav1.Time `json:"created,omitempty"`
+
+	// Expiry is when a Linode Personal Access Token expires
+	// +optional
+	Expiry *metav1.Time `json:"expiry,omitempty"`
+
+	// PreviousIds are the IDs of the Linode Personal Access Tokens replaced by
+	// the current token. They are revoked once the current token has been
+	// published to the connection secret.
+	// +optional
+	PreviousIds []int `json:"previousIds,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:printcolumn:name="LABEL",type="string",JSONPath=".status.label",description="Label of this Linode Personal Access Token",priority=1
+// +kubebuilder:printcolumn:name="SCOPES",type="string",JSONPath=".status.scopes",description="OAuth scopes of this Linode Personal Access Token",priority=1
+// +kubebuilder:printcolumn:name="EXPIRY",type="date",JSONPath=".status.expiry",description="When this Linode Personal Access Token expires",priority=1
+
+// PersonalAccessToken is the Schema for the personalaccesstokens API
+type PersonalAccessToken struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec PersonalAccessTokenSpec `json:"spec,omitempty"`
+	// +optional
+	Status PersonalAccessTokenStatus `json:"status,omitempty"`
+}
+
+// GetProviderReference of this PersonalAccessToken.
+func (a *PersonalAccessToken) GetProviderReference() *corev1.ObjectReference {
+	return a.Spec.ProviderReference
+}
+
+// SetBindingPhase of this PersonalAccessToken.
+func (a *PersonalAccessToken) SetBindingPhase(p runtimev1alpha1.BindingPhase) {
+	a.Status.SetBindingPhase(p)
+}
+
+// GetBindingPhase of this PersonalAccessToken.
+func (a *PersonalAccessToken) GetBindingPhase() runtimev1alpha1.BindingPhase {
+	return a.Status.GetBindingPhase()
+}
+
+// SetConditions of this PersonalAccessToken.
+func (a *PersonalAccessToken) SetConditions(c ...runtimev1alpha1.Condition) {
+	a.Status.SetConditions(c...)
+}
+
+// GetCondition of this PersonalAccessToken.
+func (a *PersonalAccessToken) GetCondition(ct runtimev1alpha1.ConditionType) runtimev1alpha1.Condition {
+	return GetCondition(a.Status.ConditionedStatus, ct)
+}
+
+// SetClaimReference of this PersonalAccessToken.
+func (a *PersonalAccessToken) SetClaimReference(r *corev1.ObjectReference) {
+	a.Spec.ClaimReference = r
+}
+
+// GetClaimReference of this PersonalAccessToken.
+func (a *PersonalAccessToken) GetClaimReference() *corev1.ObjectReference {
+	return a.Spec.ClaimReference
+}
+
+// SetNonPortableClassReference of this PersonalAccessToken.
+func (a *PersonalAccessToken) SetNonPortableClassReference(r *corev1.ObjectReference) {
+	a.Spec.NonPortableClassReference = r
+}
+
+// GetNonPortableClassReference of this PersonalAccessToken.
+func (a *PersonalAccessToken) GetNonPortableClassReference() *corev1.ObjectReference {
+	return a.Spec.NonPortableClassReference
+}
+
+// SetWriteConnectionSecretToReference of this PersonalAccessToken.
+func (a *PersonalAccessToken) SetWriteConnectionSecretToReference(r corev1.LocalObjectReference) {
+	a.Spec.WriteConnectionSecretToReference = r
+}
+
+// GetWriteConnectionSecretToReference of this PersonalAccessToken.
+func (a *PersonalAccessToken) GetWriteConnectionSecretToReference() corev1.LocalObjectReference {
+	return a.Spec.WriteConnectionSecretToReference
+}
+
+// GetManagementPolicy of this PersonalAccessToken.
+func (a *PersonalAccessToken) GetManagementPolicy() string {
+	return a.Spec.ManagementPolicy
+}
+
+// GetReclaimPolicy of this PersonalAccessToken.
+func (a *PersonalAccessToken) GetReclaimPolicy() runtimev1alpha1.ReclaimPolicy {
+	return a.Spec.ReclaimPolicy
+}
+
+// SetReclaimPolicy of this PersonalAccessToken.
+func (a *PersonalAccessToken) SetReclaimPolicy(p runtimev1alpha1.ReclaimPolicy) {
+	a.Spec.ReclaimPolicy = p
+}
+
+// +kubebuilder:object:root=true
+
+// PersonalAccessTokenList contains a list of PersonalAccessToken
+type PersonalAccessTokenList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PersonalAccessToken `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&PersonalAccessToken{}, &PersonalAccessTokenList{})
+}
@@ -17,27 +17,113 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"reflect"
+
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+)
+
+var (
+	ProviderKind             = reflect.TypeOf(Provider{}).Name()
+	ProviderKindAPIVersion   = ProviderKind + "." + GroupVersion.String()
+	ProviderGroupVersionKind = GroupVersion.WithKind(ProviderKind)
 )
 
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
+// Sources of Provider credentials.
+const (
+	CredentialsSourceSecret      = "Secret"
+	CredentialsSourceEnvironment = "Environment"
+	CredentialsSourceFilesystem  = "Filesystem"
+)
+
+// DefaultCredentialsEnvironmentVariable is the environment variable that
+// Provider credentials are read from when none is specified.
+const DefaultCredentialsEnvironmentVariable = "LINODE_TOKEN"
+
+// EnvironmentCredentialsSelector selects an environment variable of the
+// controller process holding Provider credentials
+type EnvironmentCredentialsSelector struct {
+	// Name is the name of the environment variable. Defaults to LINODE_TOKEN.
+	// +optional
+	Name string `json:"name,omitempty"`
+}
+
+// FilesystemCredentialsSelector selects a file in the controller's filesystem
+// holding Provider credentials, such as a projected volume or a token
+// injected by a secret store agent
+type FilesystemCredentialsSelector struct {
+	// Path is the absolute path of the file. The file is read again whenever it changes.
+	Path string `json:"path"`
+}
+
+// ProviderCredentials define where the credentials of a Provider are read from
+type ProviderCredentials struct {
+	// Source of the credentials. The Secret referenced by credentialsSecretRef is used by default.
+	// +kubebuilder:validation:Enum=Secret;Environment;Filesystem
+	// +optional
+	Source string `json:"source,omitempty"`
+
+	// Env selects the environment variable holding the credentials when the Source is Environment
+	// +optional
+	Env *EnvironmentCredentialsSelector `json:"env,omitempty"`
+
+	// Fs selects the file holding the credentials when the Source is Filesystem
+	// +optional
+	Fs *FilesystemCredentialsSelector `json:"fs,omitempty"`
+}
+
 // ProviderSpec defines the desired state of Provider
 type ProviderSpec struct {
-	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
-	// Important: Run "make" to regenerate code after modifying this file
-	Secret corev1.SecretKeySelector `json:"credentialsSecretRef"`
+	// Secret references the key of a Secret in the Provider's namespace holding
+	// the credentials, when the credentials Source is Secret
+	// +optional
+	Secret corev1.SecretKeySelector `json:"credentialsSecretRef,omitempty"`
+
+	// Credentials define where the credentials of the Provider are read from
+	// +optional
+	Credentials *ProviderCredentials `json:"credentials,omitempty"`
+}
+
+// CredentialsSource returns the source of the Provider's credentials.
+func (p *Provider) CredentialsSource() string {
+	if p.Spec.Credentials == nil || p.Spec.Credentials.Source == "" {
+		return CredentialsSourceSecret
+	}
+	return p.Spec.Credentials.Source
 }
 
 // ProviderStatus defines the observed state of Provider
 type ProviderStatus struct {
-	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
-	// Important: Run "make" to regenerate code after modifying this file
+	runtimev1alpha1.ConditionedStatus `json:",inline"`
+
+	// Username is the name of the Linode user the credentials belong to
+	// +optional
+	Username string `json:"username,omitempty"`
+
+	// Email is the email address of the Linode user the credentials belong to
+	// +optional
+	Email string `json:"email,omitempty"`
+
+	// UserID is the unique ID of the Linode user the credentials belong to
+	// +optional
+	UserID int `json:"userID,omitempty"`
+
+	// Scopes are the OAuth scopes of the credentials
+	// +optional
+	Scopes string `json:"scopes,omitempty"`
+
+	// TokenExpiry is when the credentials expire, if they are a Personal Access Token that expires
+	// +optional
+	TokenExpiry *metav1.Time `json:"tokenExpiry,omitempty"`
 }
 
 // +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
 
 // Provider is the Schema for the providers API
 type Provider struct {
@@ -48,6 +134,16 @@ type Provider struct {
 	Status ProviderStatus `json:"status,omitempty"`
 }
 
+// SetConditions of this Provider.
+func (a *Provider) SetConditions(c ...runtimev1alpha1.Condition) {
+	a.Status.SetConditions(c...)
+}
+
+// GetCondition of this Provider.
+func (a *Provider) GetCondition(ct runtimev1alpha1.ConditionType) runtimev1alpha1.Condition {
+	return GetCondition(a.Status.ConditionedStatus, ct)
+}
+
 // +kubebuilder:object:root=true
 
 // ProviderList contains a list of Provider
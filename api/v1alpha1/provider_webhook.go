@@ -0,0 +1,113 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"path/filepath"
+	"regexp"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// +kubebuilder:webhook:path=/mutate-linode-stack-crossplane-io-v1alpha1-provider,mutating=true,failurePolicy=fail,groups=linode.stack.crossplane.io,resources=providers,verbs=create;update,versions=v1alpha1,name=mprovider.linode.stack.crossplane.io
+// +kubebuilder:webhook:path=/validate-linode-stack-crossplane-io-v1alpha1-provider,mutating=false,failurePolicy=fail,groups=linode.stack.crossplane.io,resources=providers,verbs=create;update,versions=v1alpha1,name=vprovider.linode.stack.crossplane.io
+
+var _ webhook.Defaulter = &Provider{}
+var _ webhook.Validator = &Provider{}
+
+var environmentVariablePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// SetupWebhookWithManager registers the defaulting and validating webhooks
+// of the Provider with the supplied manager.
+func (r *Provider) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(r).Complete()
+}
+
+// Default the credentials source of a Provider to Secret, and the environment
+// variable of Environment credentials to LINODE_TOKEN.
+func (r *Provider) Default() {
+	if r.Spec.Credentials == nil {
+		return
+	}
+	if r.Spec.Credentials.Source == "" {
+		r.Spec.Credentials.Source = CredentialsSourceSecret
+	}
+	if r.Spec.Credentials.Source == CredentialsSourceEnvironment {
+		if r.Spec.Credentials.Env == nil {
+			r.Spec.Credentials.Env = &EnvironmentCredentialsSelector{}
+		}
+		if r.Spec.Credentials.Env.Name == "" {
+			r.Spec.Credentials.Env.Name = DefaultCredentialsEnvironmentVariable
+		}
+	}
+}
+
+// ValidateCreate validates the credentials of a new Provider.
+func (r *Provider) ValidateCreate() error {
+	return r.invalid(r.validateCredentials())
+}
+
+// ValidateUpdate validates the credentials of an updated Provider.
+func (r *Provider) ValidateUpdate(_ runtime.Object) error {
+	return r.invalid(r.validateCredentials())
+}
+
+// ValidateDelete allows every Provider to be deleted.
+func (r *Provider) ValidateDelete() error {
+	return nil
+}
+
+func (r *Provider) invalid(errs field.ErrorList) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(ProviderGroupVersionKind.GroupKind(), r.GetName(), errs)
+}
+
+// validateCredentials validates that the selector of the Provider's
+// credentials source is complete.
+func (r *Provider) validateCredentials() field.ErrorList {
+	p := field.NewPath("spec")
+	errs := field.ErrorList{}
+
+	switch r.CredentialsSource() {
+	case CredentialsSourceSecret:
+		if r.Spec.Secret.Name == "" {
+			errs = append(errs, field.Required(p.Child("credentialsSecretRef", "name"), "is required when the credentials source is Secret"))
+		}
+		if r.Spec.Secret.Key == "" {
+			errs = append(errs, field.Required(p.Child("credentialsSecretRef", "key"), "is required when the credentials source is Secret"))
+		}
+	case CredentialsSourceEnvironment:
+		if env := r.Spec.Credentials.Env; env != nil && env.Name != "" && !environmentVariablePattern.MatchString(env.Name) {
+			errs = append(errs, field.Invalid(p.Child("credentials", "env", "name"), env.Name, "must be a valid environment variable name"))
+		}
+	case CredentialsSourceFilesystem:
+		fs := r.Spec.Credentials.Fs
+		switch {
+		case fs == nil || fs.Path == "":
+			errs = append(errs, field.Required(p.Child("credentials", "fs", "path"), "is required when the credentials source is Filesystem"))
+		case !filepath.IsAbs(fs.Path):
+			errs = append(errs, field.Invalid(p.Child("credentials", "fs", "path"), fs.Path, "must be an absolute path"))
+		}
+	}
+	return errs
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestProviderDefault(t *testing.T) {
+	cases := map[string]struct {
+		credentials *ProviderCredentials
+		want        *ProviderCredentials
+	}{
+		"NoCredentials": {},
+		"SourceFromSecret": {
+			credentials: &ProviderCredentials{},
+			want:        &ProviderCredentials{Source: CredentialsSourceSecret},
+		},
+		"EnvironmentVariable": {
+			credentials: &ProviderCredentials{Source: CredentialsSourceEnvironment},
+			want:        &ProviderCredentials{Source: CredentialsSourceEnvironment, Env: &EnvironmentCredentialsSelector{Name: DefaultCredentialsEnvironmentVariable}},
+		},
+		"EnvironmentVariableKept": {
+			credentials: &ProviderCredentials{Source: CredentialsSourceEnvironment, Env: &EnvironmentCredentialsSelector{Name: "TOKEN"}},
+			want:        &ProviderCredentials{Source: CredentialsSourceEnvironment, Env: &EnvironmentCredentialsSelector{Name: "TOKEN"}},
+		},
+		"Filesystem": {
+			credentials: &ProviderCredentials{Source: CredentialsSourceFilesystem, Fs: &FilesystemCredentialsSelector{Path: "/token"}},
+			want:        &ProviderCredentials{Source: CredentialsSourceFilesystem, Fs: &FilesystemCredentialsSelector{Path: "/token"}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := &Provider{}
+			r.Spec.Credentials = tc.credentials
+			r.Default()
+			if !reflect.DeepEqual(r.Spec.Credentials, tc.want) {
+				t.Errorf("Default(): want credentials %+v, got %+v", tc.want, r.Spec.Credentials)
+			}
+		})
+	}
+}
+
+func TestProviderValidateCreate(t *testing.T) {
+	secret := corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "linode"}, Key: "token"}
+
+	cases := map[string]struct {
+		secret      corev1.SecretKeySelector
+		credentials *ProviderCredentials
+		want        []string
+	}{
+		"Secret": {
+			secret: secret,
+		},
+		"SecretMissing": {
+			want: []string{"spec.credentialsSecretRef.name", "spec.credentialsSecretRef.key"},
+		},
+		"Environment": {
+			credentials: &ProviderCredentials{Source: CredentialsSourceEnvironment, Env: &EnvironmentCredentialsSelector{Name: "LINODE_TOKEN"}},
+		},
+		"EnvironmentVariableInvalid": {
+			credentials: &ProviderCredentials{Source: CredentialsSourceEnvironment, Env: &EnvironmentCredentialsSelector{Name: "LINODE-TOKEN"}},
+			want:        []string{"spec.credentials.env.name"},
+		},
+		"Filesystem": {
+			credentials: &ProviderCredentials{Source: CredentialsSourceFilesystem, Fs: &FilesystemCredentialsSelector{Path: "/var/run/secrets/linode/token"}},
+		},
+		"FilesystemPathMissing": {
+			credentials: &ProviderCredentials{Source: CredentialsSourceFilesystem},
+			want:        []string{"spec.credentials.fs.path"},
+		},
+		"FilesystemPathRelative": {
+			credentials: &ProviderCredentials{Source: CredentialsSourceFilesystem, Fs: &FilesystemCredentialsSelector{Path: "token"}},
+			want:        []string{"spec.credentials.fs.path"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := &Provider{}
+			r.Spec.Secret = tc.secret
+			r.Spec.Credentials = tc.credentials
+			if got := invalidFields(t, r.ValidateCreate()); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("ValidateCreate(): want invalid fields %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+)
+
+var (
+	ProviderConfigKind             = reflect.TypeOf(ProviderConfig{}).Name()
+	ProviderConfigKindAPIVersion   = ProviderConfigKind + "." + GroupVersion.String()
+	ProviderConfigGroupVersionKind = GroupVersion.WithKind(ProviderConfigKind)
+)
+
+// SecretKeySelector selects a key of a Secret in any namespace
+type SecretKeySelector struct {
+	// Namespace of the Secret
+	Namespace string `json:"namespace"`
+
+	// Name of the Secret
+	Name string `json:"name"`
+
+	// Key of the Secret holding the value
+	Key string `json:"key"`
+}
+
+// ProviderConfigSpec defines the desired state of ProviderConfig
+type ProviderConfigSpec struct {
+	// Secret references the key of a Secret holding the credentials, when the
+	// credentials Source is Secret
+	// +optional
+	Secret *SecretKeySelector `json:"credentialsSecretRef,omitempty"`
+
+	// Credentials define where the credentials of the ProviderConfig are read from
+	// +optional
+	Credentials *ProviderCredentials `json:"credentials,omitempty"`
+}
+
+// CredentialsSource returns the source of the ProviderConfig's credentials.
+func (p *ProviderConfig) CredentialsSource() string {
+	if p.Spec.Credentials == nil || p.Spec.Credentials.Source == "" {
+		return CredentialsSourceSecret
+	}
+	return p.Spec.Credentials.Source
+}
+
+// ProviderConfigStatus defines the observed state of ProviderConfig
+type ProviderConfigStatus struct {
+	runtimev1alpha1.ConditionedStatus `json:",inline"`
+
+	// Users is the number of managed resources using this ProviderConfig
+	// +optional
+	Users int64 `json:"users,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:printcolumn:name="USERS",type="integer",JSONPath=".status.users",description="Number of managed resources using this ProviderConfig"
+
+// ProviderConfig is the Schema for the providerconfigs API. Unlike a Provider,
+// a ProviderConfig is cluster scoped and may be referenced by managed
+// resources in any namespace, with a providerRef of kind ProviderConfig.
+type ProviderConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ProviderConfigSpec `json:"spec,omitempty"`
+	// +optional
+	Status ProviderConfigStatus `json:"status,omitempty"`
+}
+
+// SetConditions of this ProviderConfig.
+func (a *ProviderConfig) SetConditions(c ...runtimev1alpha1.Condition) {
+	a.Status.SetConditions(c...)
+}
+
+// GetCondition of this ProviderConfig.
+func (a *ProviderConfig) GetCondition(ct runtimev1alpha1.ConditionType) runtimev1alpha1.Condition {
+	return GetCondition(a.Status.ConditionedStatus, ct)
+}
+
+// +kubebuilder:object:root=true
+
+// ProviderConfigList contains a list of ProviderConfig
+type ProviderConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ProviderConfig `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ProviderConfig{}, &ProviderConfigList{})
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var (
+	ProviderConfigUsageKind             = reflect.TypeOf(ProviderConfigUsage{}).Name()
+	ProviderConfigUsageKindAPIVersion   = ProviderConfigUsageKind + "." + GroupVersion.String()
+	ProviderConfigUsageGroupVersionKind = GroupVersion.WithKind(ProviderConfigUsageKind)
+)
+
+// LabelProviderConfigName is the label of a ProviderConfigUsage holding the
+// name of the ProviderConfig it records a use of.
+const LabelProviderConfigName = "linode.stack.crossplane.io/provider-config"
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:printcolumn:name="CONFIG",type="string",JSONPath=".providerConfigRef.name",description="Name of the ProviderConfig in use"
+// +kubebuilder:printcolumn:name="RESOURCE-KIND",type="string",JSONPath=".resourceRef.kind",description="Kind of the managed resource using the ProviderConfig"
+// +kubebuilder:printcolumn:name="RESOURCE-NAMESPACE",type="string",JSONPath=".resourceRef.namespace",description="Namespace of the managed resource using the ProviderConfig"
+// +kubebuilder:printcolumn:name="RESOURCE-NAME",type="string",JSONPath=".resourceRef.name",description="Name of the managed resource using the ProviderConfig"
+
+// ProviderConfigUsage records that a managed resource uses a ProviderConfig.
+// ProviderConfigUsages are named after the UID of the managed resource, and
+// are created and removed by the controllers; a ProviderConfig cannot be
+// deleted while it is in use.
+type ProviderConfigUsage struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// ProviderConfigReference references the ProviderConfig in use
+	ProviderConfigReference corev1.LocalObjectReference `json:"providerConfigRef"`
+
+	// ResourceReference references the managed resource using the ProviderConfig
+	ResourceReference corev1.ObjectReference `json:"resourceRef"`
+}
+
+// +kubebuilder:object:root=true
+
+// ProviderConfigUsageList contains a list of ProviderConfigUsage
+type ProviderConfigUsageList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ProviderConfigUsage `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ProviderConfigUsage{}, &ProviderConfigUsageList{})
+}
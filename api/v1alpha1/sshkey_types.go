@@ -0,0 +1,178 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+)
+
+var (
+	SSHKeyKind             = reflect.TypeOf(SSHKey{}).Name()
+	SSHKeyKindAPIVersion   = SSHKeyKind + "." + GroupVersion.String()
+	SSHKeyGroupVersionKind = GroupVersion.WithKind(SSHKeyKind)
+)
+
+// SSHKeyParameters define the desired state of a Linode SSH Key in the profile
+// of the Linode user whose credentials the Provider holds. Exactly one of
+// PublicKey or PublicKeySecretRef must be set.
+type SSHKeyParameters struct {
+	// Label is the name of this Linode SSH Key. The name of the SSHKey is used when this is not set.
+	// +optional
+	Label string `json:"label,omitempty"`
+
+	// PublicKey is the public SSH key, such as "ssh-rsa AAAA... user@host"
+	// +optional
+	PublicKey string `json:"publicKey,omitempty"`
+
+	// PublicKeySecretRef references a key of a Secret in the same namespace holding the public SSH key
+	// +optional
+	PublicKeySecretRef *corev1.SecretKeySelector `json:"publicKeySecretRef,omitempty"`
+}
+
+// SSHKeySpec defines the desired state of SSHKey
+type SSHKeySpec struct {
+	runtimev1alpha1.ResourceSpec `json:",inline"`
+	SSHKeyParameters             `json:",inline"`
+
+	// ManagementPolicy determines whether the Linode SSH Key is created, updated
+	// and deleted, or only observed. Defaults to Full. SSHKeys that are only
+	// observed find an existing Linode SSH Key by their label.
+	// +kubebuilder:validation:Enum=Full;ObserveOnly;NoDelete
+	// +optional
+	ManagementPolicy string `json:"managementPolicy,omitempty"`
+}
+
+// SSHKeyStatus defines the observed state of SSHKey
+type SSHKeyStatus struct {
+	runtimev1alpha1.ResourceStatus `json:",inline"`
+
+	// Id is the unique immutable numeric identifier of a Linode SSH Key
+	// +optional
+	Id int `json:"id,omitempty"`
+
+	// Label is the name of a Linode SSH Key
+	// +optional
+	Label string `json:"label,omitempty"`
+
+	// PublicKey is the public SSH key of a Linode SSH Key
+	// +optional
+	PublicKey string `json:"publicKey,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:printcolumn:name="ID",type="integer",JSONPath=".status.id",description="ID of this Linode SSH Key",priority=1
+// +kubebuilder:printcolumn:name="LABEL",type="string",JSONPath=".status.label",description="Label of this Linode SSH Key",priority=1
+
+// SSHKey is the Schema for the sshkeys API
+type SSHKey struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec SSHKeySpec `json:"spec,omitempty"`
+	// +optional
+	Status SSHKeyStatus `json:"status,omitempty"`
+}
+
+// GetProviderReference of this SSHKey.
+func (a *SSHKey) GetProviderReference() *corev1.ObjectReference {
+	return a.Spec.ProviderReference
+}
+
+// SetBindingPhase of this SSHKey.
+func (a *SSHKey) SetBindingPhase(p runtimev1alpha1.BindingPhase) {
+	a.Status.SetBindingPhase(p)
+}
+
+// GetBindingPhase of this SSHKey.
+func (a *SSHKey) GetBindingPhase() runtimev1alpha1.BindingPhase {
+	return a.Status.GetBindingPhase()
+}
+
+// SetConditions of this SSHKey.
+func (a *SSHKey) SetConditions(c ...runtimev1alpha1.Condition) {
+	a.Status.SetConditions(c...)
+}
+
+// GetCondition of this SSHKey.
+func (a *SSHKey) GetCondition(ct runtimev1alpha1.ConditionType) runtimev1alpha1.Condition {
+	return GetCondition(a.Status.ConditionedStatus, ct)
+}
+
+// SetClaimReference of this SSHKey.
+func (a *SSHKey) SetClaimReference(r *corev1.ObjectReference) {
+	a.Spec.ClaimReference = r
+}
+
+// GetClaimReference of this SSHKey.
+func (a *SSHKey) GetClaimReference() *corev1.ObjectReference {
+	return a.Spec.ClaimReference
+}
+
+// SetNonPortableClassReference of this SSHKey.
+func (a *SSHKey) SetNonPortableClassReference(r *corev1.ObjectReference) {
+	a.Spec.NonPortableClassReference = r
+}
+
+// GetNonPortableClassReference of this SSHKey.
+func (a *SSHKey) GetNonPortableClassReference() *corev1.ObjectReference {
+	return a.Spec.NonPortableClassReference
+}
+
+// SetWriteConnectionSecretToReference of this SSHKey.
+func (a *SSHKey) SetWriteConnectionSecretToReference(r corev1.LocalObjectReference) {
+	a.Spec.WriteConnectionSecretToReference = r
+}
+
+// GetWriteConnectionSecretToReference of this SSHKey.
+func (a *SSHKey) GetWriteConnectionSecretToReference() corev1.LocalObjectReference {
+	return a.Spec.WriteConnectionSecretToReference
+}
+
+// GetManagementPolicy of this SSHKey.
+func (a *SSHKey) GetManagementPolicy() string {
+	return a.Spec.ManagementPolicy
+}
+
+// GetReclaimPolicy of this SSHKey.
+func (a *SSHKey) GetReclaimPolicy() runtimev1alpha1.ReclaimPolicy {
+	return a.Spec.ReclaimPolicy
+}
+
+// SetReclaimPolicy of this SSHKey.
+func (a *SSHKey) SetReclaimPolicy(p runtimev1alpha1.ReclaimPolicy) {
+	a.Spec.ReclaimPolicy = p
+}
+
+// +kubebuilder:object:root=true
+
+// SSHKeyList contains a list of SSHKey
+type SSHKeyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SSHKey `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&SSHKey{}, &SSHKeyList{})
+}
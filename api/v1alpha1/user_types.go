@@ -0,0 +1,175 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+)
+
+var (
+	UserKind             = reflect.TypeOf(User{}).Name()
+	UserKindAPIVersion   = UserKind + "." + GroupVersion.String()
+	UserGroupVersionKind = GroupVersion.WithKind(UserKind)
+)
+
+// UserParameters define the desired state of a Linode User
+type UserParameters struct {
+	// Username is the unique name of this Linode User, used to log in to Linode
+	Username string `json:"username"`
+
+	// Email is the email address of this Linode User, where the invitation to set a password is sent
+	Email string `json:"email"`
+
+	// Restricted Users may only access the entities and perform the actions granted by UserGrants
+	// +optional
+	Restricted bool `json:"restricted,omitempty"`
+}
+
+// UserSpec defines the desired state of User
+type UserSpec struct {
+	runtimev1alpha1.ResourceSpec `json:",inline"`
+	UserParameters               `json:",inline"`
+
+	// ManagementPolicy determines whether the Linode User is created, updated
+	// and deleted, or only observed. Defaults to Full. Users that are only
+	// observed find an existing Linode User by their username.
+	// +kubebuilder:validation:Enum=Full;ObserveOnly;NoDelete
+	// +optional
+	ManagementPolicy string `json:"managementPolicy,omitempty"`
+}
+
+// UserStatus defines the observed state of User
+type UserStatus struct {
+	runtimev1alpha1.ResourceStatus `json:",inline"`
+
+	// Username is the unique name of a Linode User
+	// +optional
+	Username string `json:"username,omitempty"`
+
+	// Email is the email address of a Linode User
+	// +optional
+	Email string `json:"email,omitempty"`
+
+	// Restricted is true when a Linode User is restricted
+	// +optional
+	Restricted bool `json:"restricted,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:printcolumn:name="USERNAME",type="string",JSONPath=".status.username",description="Username of this Linode User",priority=1
+// +kubebuilder:printcolumn:name="EMAIL",type="string",JSONPath=".status.email",description="Email address of this Linode User",priority=1
+// +kubebuilder:printcolumn:name="RESTRICTED",type="boolean",JSONPath=".status.restricted",description="Whether this Linode User is restricted",priority=1
+
+// User is the Schema for the users API
+type User struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec UserSpec `json:"spec,omitempty"`
+	// +optional
+	Status UserStatus `json:"status,omitempty"`
+}
+
+// GetProviderReference of this User.
+func (a *User) GetProviderReference() *corev1.ObjectReference {
+	return a.Spec.ProviderReference
+}
+
+// SetBindingPhase of this User.
+func (a *User) SetBindingPhase(p runtimev1alpha1.BindingPhase) {
+	a.Status.SetBindingPhase(p)
+}
+
+// GetBindingPhase of this User.
+func (a *User) GetBindingPhase() runtimev1alpha1.BindingPhase {
+	return a.Status.GetBindingPhase()
+}
+
+// SetConditions of this User.
+func (a *User) SetConditions(c ...runtimev1alpha1.Condition) {
+	a.Status.SetConditions(c...)
+}
+
+// GetCondition of this User.
+func (a *User) GetCondition(ct runtimev1alpha1.ConditionType) runtimev1alpha1.Condition {
+	return GetCondition(a.Status.ConditionedStatus, ct)
+}
+
+// SetClaimReference of this User.
+func (a *User) SetClaimReference(r *corev1.ObjectReference) {
+	a.Spec.ClaimReference = r
+}
+
+// GetClaimReference of this User.
+func (a *User) GetClaimReference() *corev1.ObjectReference {
+	return a.Spec.ClaimReference
+}
+
+// SetNonPortableClassReference of this User.
+func (a *User) SetNonPortableClassReference(r *corev1.ObjectReference) {
+	a.Spec.NonPortableClassReference = r
+}
+
+// GetNonPortableClassReference of this User.
+func (a *User) GetNonPortableClassReference() *corev1.ObjectReference {
+	return a.Spec.NonPortableClassReference
+}
+
+// SetWriteConnectionSecretToReference of this User.
+func (a *User) SetWriteConnectionSecretToReference(r corev1.LocalObjectReference) {
+	a.Spec.WriteConnectionSecretToReference = r
+}
+
+// GetWriteConnectionSecretToReference of this User.
+func (a *User) GetWriteConnectionSecretToReference() corev1.LocalObjectReference {
+	return a.Spec.WriteConnectionSecretToReference
+}
+
+// GetManagementPolicy of this User.
+func (a *User) GetManagementPolicy() string {
+	return a.Spec.ManagementPolicy
+}
+
+// GetReclaimPolicy of this User.
+func (a *User) GetReclaimPolicy() runtimev1alpha1.ReclaimPolicy {
+	return a.Spec.ReclaimPolicy
+}
+
+// SetReclaimPolicy of this User.
+func (a *User) SetReclaimPolicy(p runtimev1alpha1.ReclaimPolicy) {
+	a.Spec.ReclaimPolicy = p
+}
+
+// +kubebuilder:object:root=true
+
+// UserList contains a list of User
+type UserList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []User `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&User{}, &UserList{})
+}
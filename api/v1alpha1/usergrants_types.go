@@ -0,0 +1,255 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+)
+
+var (
+	UserGrantsKind             = reflect.TypeOf(UserGrants{}).Name()
+	UserGrantsKindAPIVersion   = UserGrantsKind + "." + GroupVersion.String()
+	UserGrantsGroupVersionKind = GroupVersion.WithKind(UserGrantsKind)
+)
+
+// UserGlobalGrants define the account-wide grants of a restricted Linode User
+type UserGlobalGrants struct {
+	// AccountAccess is the level of access to the Account. The User has no access to the Account when this is not set.
+	// +kubebuilder:validation:Enum=read_only;read_write
+	// +optional
+	AccountAccess string `json:"accountAccess,omitempty"`
+
+	// AddDomains allows the User to create Domains
+	// +optional
+	AddDomains bool `json:"addDomains,omitempty"`
+
+	// AddImages allows the User to create Images
+	// +optional
+	AddImages bool `json:"addImages,omitempty"`
+
+	// AddLinodes allows the User to create Linode Instances
+	// +optional
+	AddLinodes bool `json:"addLinodes,omitempty"`
+
+	// AddLongview allows the User to create Longview Clients
+	// +optional
+	AddLongview bool `json:"addLongview,omitempty"`
+
+	// AddNodeBalancers allows the User to create NodeBalancers
+	// +optional
+	AddNodeBalancers bool `json:"addNodeBalancers,omitempty"`
+
+	// AddStackScripts allows the User to create StackScripts
+	// +optional
+	AddStackScripts bool `json:"addStackScripts,omitempty"`
+
+	// AddVolumes allows the User to create Volumes
+	// +optional
+	AddVolumes bool `json:"addVolumes,omitempty"`
+
+	// CancelAccount allows the User to cancel the Account
+	// +optional
+	CancelAccount bool `json:"cancelAccount,omitempty"`
+
+	// LongviewSubscription allows the User to manage the Longview subscription
+	// +optional
+	LongviewSubscription bool `json:"longviewSubscription,omitempty"`
+}
+
+// UserEntityGrant defines the grant of a restricted Linode User to a single
+// entity. Exactly one of ID, InstanceRef or ImageRef must be set.
+type UserEntityGrant struct {
+	// Type is the type of the entity
+	// +kubebuilder:validation:Enum=linode;domain;nodebalancer;image;longview;stackscript;volume
+	Type string `json:"type"`
+
+	// ID is the ID of the entity
+	// +optional
+	ID int `json:"id,omitempty"`
+
+	// InstanceRef references an Instance in the same namespace, for entities of type linode
+	// +optional
+	InstanceRef *corev1.LocalObjectReference `json:"instanceRef,omitempty"`
+
+	// ImageRef references an Image in the same namespace, for entities of type image
+	// +optional
+	ImageRef *corev1.LocalObjectReference `json:"imageRef,omitempty"`
+
+	// Permissions is the level of access to the entity
+	// +kubebuilder:validation:Enum=read_only;read_write
+	Permissions string `json:"permissions"`
+}
+
+// UserGrantsParameters define the desired grants of a restricted Linode User.
+// Only the global grants and the entities listed are managed; grants to other
+// entities are left unchanged.
+type UserGrantsParameters struct {
+	// UserRef references the User in the same namespace that is granted access
+	// +optional
+	UserRef *corev1.LocalObjectReference `json:"userRef,omitempty"`
+
+	// Username is the name of the Linode User that is granted access, when UserRef is not set
+	// +optional
+	Username string `json:"username,omitempty"`
+
+	// Global are the account-wide grants of the User. Global grants are left unchanged when this is not set.
+	// +optional
+	Global *UserGlobalGrants `json:"global,omitempty"`
+
+	// Entities are the grants of the User to individual entities
+	// +optional
+	Entities []UserEntityGrant `json:"entities,omitempty"`
+}
+
+// UserGrantsSpec defines the desired state of UserGrants
+type UserGrantsSpec struct {
+	runtimev1alpha1.ResourceSpec `json:",inline"`
+	UserGrantsParameters         `json:",inline"`
+
+	// ManagementPolicy determines whether the grants of the Linode User are
+	// revoked when the UserGrants is deleted. Defaults to Full. UserGrants may
+	// not be only observed.
+	// +kubebuilder:validation:Enum=Full;NoDelete
+	// +optional
+	ManagementPolicy string `json:"managementPolicy,omitempty"`
+}
+
+// UserGrantsObservedEntity is an entity a restricted Linode User was granted access to
+type UserGrantsObservedEntity struct {
+	// Type is the type of the entity
+	Type string `json:"type"`
+
+	// ID is the ID of the entity
+	ID int `json:"id"`
+}
+
+// UserGrantsStatus defines the observed state of UserGrants
+type UserGrantsStatus struct {
+	runtimev1alpha1.ResourceStatus `json:",inline"`
+
+	// Username is the name of the Linode User that is granted access
+	// +optional
+	Username string `json:"username,omitempty"`
+
+	// Entities are the entities the Linode User was granted access to, whose
+	// access is revoked when the UserGrants is deleted
+	// +optional
+	Entities []UserGrantsObservedEntity `json:"entities,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:printcolumn:name="USERNAME",type="string",JSONPath=".status.username",description="Username of the Linode User that is granted access",priority=1
+
+// UserGrants is the Schema for the usergrants API
+type UserGrants struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec UserGrantsSpec `json:"spec,omitempty"`
+	// +optional
+	Status UserGrantsStatus `json:"status,omitempty"`
+}
+
+// GetProviderReference of this UserGrants.
+func (a *UserGrants) GetProviderReference() *corev1.ObjectReference {
+	return a.Spec.ProviderReference
+}
+
+// SetBindingPhase of this UserGrants.
+func (a *UserGrants) SetBindingPhase(p runtimev1alpha1.BindingPhase) {
+	a.Status.SetBindingPhase(p)
+}
+
+// GetBindingPhase of this UserGrants.
+func (a *UserGrants) GetBindingPhase() runtimev1alpha1.BindingPhase {
+	return a.Status.GetBindingPhase()
+}
+
+// SetConditions of this UserGrants.
+func (a *UserGrants) SetConditions(c ...runtimev1alpha1.Condition) {
+	a.Status.SetConditions(c...)
+}
+
+// GetCondition of this UserGrants.
+func (a *UserGrants) GetCondition(ct runtimev1alpha1.ConditionType) runtimev1alpha1.Condition {
+	return GetCondition(a.Status.ConditionedStatus, ct)
+}
+
+// SetClaimReference of this UserGrants.
+func (a *UserGrants) SetClaimReference(r *corev1.ObjectReference) {
+	a.Spec.ClaimReference = r
+}
+
+// GetClaimReference of this UserGrants.
+func (a *UserGrants) GetClaimReference() *corev1.ObjectReference {
+	return a.Spec.ClaimReference
+}
+
+// SetNonPortableClassReference of this UserGrants.
+func (a *UserGrants) SetNonPortableClassReference(r *corev1.ObjectReference) {
+	a.Spec.NonPortableClassReference = r
+}
+
+// GetNonPortableClassReference of this UserGrants.
+func (a *UserGrants) GetNonPortableClassReference() *corev1.ObjectReference {
+	return a.Spec.NonPortableClassReference
+}
+
+// SetWriteConnectionSecretToReference of this UserGrants.
+func (a *UserGrants) SetWriteConnectionSecretToReference(r corev1.LocalObjectReference) {
+	a.Spec.WriteConnectionSecretToReference = r
+}
+
+// GetWriteConnectionSecretToReference of this UserGrants.
+func (a *UserGrants) GetWriteConnectionSecretToReference() corev1.LocalObjectReference {
+	return a.Spec.WriteConnectionSecretToReference
+}
+
+// GetManagementPolicy of this UserGrants.
+func (a *UserGrants) GetManagementPolicy() string {
+	return a.Spec.ManagementPolicy
+}
+
+// GetReclaimPolicy of this UserGrants.
+func (a *UserGrants) GetReclaimPolicy() runtimev1alpha1.ReclaimPolicy {
+	return a.Spec.ReclaimPolicy
+}
+
+// SetReclaimPolicy of this UserGrants.
+func (a *UserGrants) SetReclaimPolicy(p runtimev1alpha1.ReclaimPolicy) {
+	a.Spec.ReclaimPolicy = p
+}
+
+// +kubebuilder:object:root=true
+
+// UserGrantsList contains a list of UserGrants
+type UserGrantsList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []UserGrants `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&UserGrants{}, &UserGrantsList{})
+}
@@ -0,0 +1,347 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package catalog caches the regions, Instance types and public images
+// offered by Linode, so that managed resources can be validated before any
+// request is made to the Linode API.
+package catalog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/linode/linodego"
+	"github.com/pkg/errors"
+)
+
+const (
+	errDecodeSnapshot = "cannot decode catalog snapshot"
+	errListRegions    = "cannot list Linode regions"
+	errListTypes      = "cannot list Linode Instance types"
+	errListImages     = "cannot list Linode public images"
+)
+
+// Kinds of catalog entries.
+const (
+	KindRegion = "region"
+	KindType   = "Instance type"
+	KindImage  = "image"
+)
+
+// PublicImagePrefix is the prefix of the IDs of images published by Linode.
+// Private images belong to an account and are not part of the catalog.
+const PublicImagePrefix = "linode/"
+
+// maxSuggestions is the number of similar IDs suggested for an unknown ID.
+const maxSuggestions = 3
+
+// A snapshot of the catalog, in the form returned by the Linode API.
+type snapshot struct {
+	Regions []linodego.Region     `json:"regions"`
+	Types   []linodego.LinodeType `json:"types"`
+	Images  []linodego.Image      `json:"images"`
+}
+
+// A Catalog of Linode regions, Instance types and public images. A Catalog is
+// safe for concurrent use.
+type Catalog struct {
+	mu      sync.RWMutex
+	regions map[string]linodego.Region
+	types   map[string]linodego.LinodeType
+	images  map[string]linodego.Image
+	updated time.Time
+}
+
+// New returns an empty Catalog, which considers every ID valid until it is
+// refreshed.
+func New() *Catalog {
+	return &Catalog{}
+}
+
+// NewFromSnapshot returns a Catalog seeded from the snapshot embedded in the
+// controller, for use until it can be refreshed from the Linode API.
+func NewFromSnapshot() (*Catalog, error) {
+	c := New()
+	return c, c.Load([]byte(embeddedSnapshot))
+}
+
+// Load replaces the content of the Catalog with the supplied JSON snapshot.
+func (c *Catalog) Load(data []byte) error {
+	s := snapshot{}
+	if err := json.Unmarshal(data, &s); err != nil {
+		return errors.Wrap(err, errDecodeSnapshot)
+	}
+	c.set(s, time.Time{})
+	return nil
+}
+
+// Refresh replaces the content of the Catalog with the regions, Instance
+// types and public images currently offered by the supplied client. The
+// content is left unchanged if any of them cannot be listed.
+func (c *Catalog) Refresh(ctx context.Context, client *linodego.Client) error {
+	regions, err := client.ListRegions(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, errListRegions)
+	}
+	types, err := client.ListTypes(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, errListTypes)
+	}
+	images, err := client.ListImages(ctx, linodego.NewListOptions(0, `{"is_public": true}`))
+	if err != nil {
+		return errors.Wrap(err, errListImages)
+	}
+	c.set(snapshot{Regions: regions, Types: types, Images: images}, time.Now())
+	return nil
+}
+
+func (c *Catalog) set(s snapshot, updated time.Time) {
+	regions := make(map[string]linodego.Region, len(s.Regions))
+	for _, r := range s.Regions {
+		regions[r.ID] = r
+	}
+	types := make(map[string]linodego.LinodeType, len(s.Types))
+	for _, t := range s.Types {
+		types[t.ID] = t
+	}
+	images := make(map[string]linodego.Image, len(s.Images))
+	for _, i := range s.Images {
+		images[i.ID] = i
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.regions, c.types, c.images, c.updated = regions, types, images, updated
+}
+
+// Updated returns when the Catalog was last refreshed from the Linode API.
+// It returns the zero time for Catalogs only loaded from a snapshot.
+func (c *Catalog) Updated() time.Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.updated
+}
+
+// Region returns the region with the supplied ID, if it is in the Catalog.
+func (c *Catalog) Region(id string) (linodego.Region, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	r, ok := c.regions[id]
+	return r, ok
+}
+
+// Type returns the Instance type with the supplied ID, including its price
+// and capabilities, if it is in the Catalog.
+func (c *Catalog) Type(id string) (linodego.LinodeType, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	t, ok := c.types[id]
+	return t, ok
+}
+
+// Image returns the public image with the supplied ID, if it is in the
+// Catalog.
+func (c *Catalog) Image(id string) (linodego.Image, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	i, ok := c.images[id]
+	return i, ok
+}
+
+// MonthlyCost returns the monthly price, in US dollars, of an Instance of the
+// type with the supplied ID, if it is in the Catalog.
+func (c *Catalog) MonthlyCost(typ string) (float64, bool) {
+	t, ok := c.Type(typ)
+	if !ok || t.Price == nil {
+		return 0, false
+	}
+	return float64(t.Price.Monthly), true
+}
+
+// BackupsMonthlyCost returns the monthly price, in US dollars, of the Backups
+// of an Instance of the type with the supplied ID, if it is in the Catalog.
+func (c *Catalog) BackupsMonthlyCost(typ string) (float64, bool) {
+	t, ok := c.Type(typ)
+	if !ok || t.Addons == nil || t.Addons.Backups == nil || t.Addons.Backups.Price == nil {
+		return 0, false
+	}
+	return float64(t.Addons.Backups.Price.Monthly), true
+}
+
+// ValidateRegion returns an *UnknownError if the Catalog does not contain
+// the region with the supplied ID. Catalogs that have not yet been refreshed
+// from the Linode API consider every region valid, since their snapshot may
+// predate it.
+func (c *Catalog) ValidateRegion(id string) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if _, ok := c.regions[id]; ok || c.updated.IsZero() {
+		return nil
+	}
+	ids := make([]string, 0, len(c.regions))
+	for k := range c.regions {
+		ids = append(ids, k)
+	}
+	return unknown(KindRegion, id, ids)
+}
+
+// ValidateType returns an *UnknownError if the Catalog does not contain the
+// Instance type with the supplied ID. Catalogs that have not yet been
+// refreshed from the Linode API consider every Instance type valid.
+func (c *Catalog) ValidateType(id string) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if _, ok := c.types[id]; ok || c.updated.IsZero() {
+		return nil
+	}
+	ids := make([]string, 0, len(c.types))
+	for k := range c.types {
+		ids = append(ids, k)
+	}
+	return unknown(KindType, id, ids)
+}
+
+// ValidateImage returns an *UnknownError if the supplied ID is that of a
+// public image the Catalog does not contain. Private images are not
+// validated, nor are any images until the Catalog has been refreshed from the
+// Linode API.
+func (c *Catalog) ValidateImage(id string) error {
+	if !strings.HasPrefix(id, PublicImagePrefix) {
+		return nil
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if _, ok := c.images[id]; ok || c.updated.IsZero() {
+		return nil
+	}
+	ids := make([]string, 0, len(c.images))
+	for k := range c.images {
+		ids = append(ids, k)
+	}
+	return unknown(KindImage, id, ids)
+}
+
+// An UnknownError is returned when an ID is not in the Catalog.
+type UnknownError struct {
+	// Kind of the unknown entry, such as region.
+	Kind string
+
+	// ID that is not in the Catalog.
+	ID string
+
+	// Suggestions are the most similar IDs in the Catalog, if any.
+	Suggestions []string
+}
+
+func (e *UnknownError) Error() string {
+	msg := fmt.Sprintf("unknown Linode %s %q", e.Kind, e.ID)
+	if len(e.Suggestions) == 0 {
+		return msg
+	}
+	return fmt.Sprintf("%s, did you mean %s?", msg, quotedList(e.Suggestions))
+}
+
+// IsUnknown returns true if the supplied error is an *UnknownError.
+func IsUnknown(err error) bool {
+	_, ok := errors.Cause(err).(*UnknownError)
+	return ok
+}
+
+func unknown(kind, id string, candidates []string) *UnknownError {
+	return &UnknownError{Kind: kind, ID: id, Suggestions: suggest(id, candidates)}
+}
+
+// suggest returns up to maxSuggestions of the supplied candidates that are
+// most similar to the supplied ID, ordered from the most similar.
+func suggest(id string, candidates []string) []string {
+	type match struct {
+		id       string
+		distance int
+	}
+
+	// Allow roughly one edit for every three characters, so that short IDs
+	// are not matched to unrelated IDs of a similar length.
+	limit := len(id) / 3
+	if limit < 2 {
+		limit = 2
+	}
+
+	matches := []match{}
+	for _, c := range candidates {
+		if d := distance(strings.ToLower(id), c); d <= limit {
+			matches = append(matches, match{id: c, distance: d})
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].distance != matches[j].distance {
+			return matches[i].distance < matches[j].distance
+		}
+		return matches[i].id < matches[j].id
+	})
+
+	s := []string{}
+	for i := 0; i < len(matches) && i < maxSuggestions; i++ {
+		s = append(s, matches[i].id)
+	}
+	return s
+}
+
+// distance returns the Levenshtein distance between the supplied strings.
+func distance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+func min(n ...int) int {
+	m := n[0]
+	for _, v := range n[1:] {
+		if v < m {
+			m = v
+		}
+	}
+	return m
+}
+
+func quotedList(s []string) string {
+	q := make([]string, len(s))
+	for i := range s {
+		q[i] = fmt.Sprintf("%q", s[i])
+	}
+	if len(q) == 1 {
+		return q[0]
+	}
+	return strings.Join(q[:len(q)-1], ", ") + " or " + q[len(q)-1]
+}
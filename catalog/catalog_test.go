@@ -0,0 +1,133 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package catalog
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNewFromSnapshot(t *testing.T) {
+	c, err := NewFromSnapshot()
+	if err != nil {
+		t.Fatalf("NewFromSnapshot(): %v", err)
+	}
+
+	if _, ok := c.Region("us-east"); !ok {
+		t.Errorf("Region(%q): not found", "us-east")
+	}
+	typ, ok := c.Type("g6-nanode-1")
+	if !ok {
+		t.Fatalf("Type(%q): not found", "g6-nanode-1")
+	}
+	if typ.Price == nil || typ.Price.Monthly != 5 {
+		t.Errorf("Type(%q).Price: want monthly price 5, got %+v", "g6-nanode-1", typ.Price)
+	}
+	if cost, ok := c.BackupsMonthlyCost("g6-standard-2"); !ok || cost != 5 {
+		t.Errorf("BackupsMonthlyCost(%q): want 5, got %v (found %t)", "g6-standard-2", cost, ok)
+	}
+	if _, ok := c.Image("linode/debian9"); !ok {
+		t.Errorf("Image(%q): not found", "linode/debian9")
+	}
+	if !c.Updated().IsZero() {
+		t.Errorf("Updated(): want zero time for a snapshot, got %v", c.Updated())
+	}
+}
+
+func TestValidate(t *testing.T) {
+	c, err := NewFromSnapshot()
+	if err != nil {
+		t.Fatalf("NewFromSnapshot(): %v", err)
+	}
+	// Only Catalogs refreshed from the Linode API reject unknown IDs.
+	c.updated = time.Now()
+
+	cases := map[string]struct {
+		validate func(string) error
+		id       string
+		want     *UnknownError
+	}{
+		"KnownRegion":   {validate: c.ValidateRegion, id: "us-east"},
+		"UnknownRegion": {validate: c.ValidateRegion, id: "us-eest", want: &UnknownError{Kind: KindRegion, ID: "us-eest", Suggestions: []string{"us-east", "us-west"}}},
+		"KnownType":     {validate: c.ValidateType, id: "g6-standard-2"},
+		"UnknownType":   {validate: c.ValidateType, id: "g6-standrd-2", want: &UnknownError{Kind: KindType, ID: "g6-standrd-2", Suggestions: []string{"g6-standard-2", "g6-standard-1", "g6-standard-20"}}},
+		"NoSuggestions": {validate: c.ValidateType, id: "m5.large", want: &UnknownError{Kind: KindType, ID: "m5.large", Suggestions: []string{}}},
+		"KnownImage":    {validate: c.ValidateImage, id: "linode/debian9"},
+		"UnknownImage":  {validate: c.ValidateImage, id: "linode/debain9", want: &UnknownError{Kind: KindImage, ID: "linode/debain9", Suggestions: []string{"linode/debian9", "linode/debian8", "linode/debian10"}}},
+		"PrivateImage":  {validate: c.ValidateImage, id: "private/12345"},
+		"EmptyCatalog":  {validate: New().ValidateRegion, id: "anywhere"},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.validate(tc.id)
+			if tc.want == nil {
+				if err != nil {
+					t.Errorf("validate(%q): want no error, got %v", tc.id, err)
+				}
+				return
+			}
+			if !reflect.DeepEqual(err, tc.want) {
+				t.Errorf("validate(%q): want %#v, got %#v", tc.id, tc.want, err)
+			}
+		})
+	}
+}
+
+func TestValidateSnapshot(t *testing.T) {
+	c, err := NewFromSnapshot()
+	if err != nil {
+		t.Fatalf("NewFromSnapshot(): %v", err)
+	}
+
+	cases := map[string]struct {
+		validate func(string) error
+		id       string
+	}{
+		"UnknownRegion": {validate: c.ValidateRegion, id: "us-newark-2"},
+		"UnknownType":   {validate: c.ValidateType, id: "g7-standard-2"},
+		"UnknownImage":  {validate: c.ValidateImage, id: "linode/debian42"},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if err := tc.validate(tc.id); err != nil {
+				t.Errorf("validate(%q): want no error from a snapshot, got %v", tc.id, err)
+			}
+		})
+	}
+}
+
+func TestUnknownError(t *testing.T) {
+	cases := map[string]struct {
+		err  *UnknownError
+		want string
+	}{
+		"NoSuggestions":  {err: &UnknownError{Kind: KindRegion, ID: "mars"}, want: `unknown Linode region "mars"`},
+		"OneSuggestion":  {err: &UnknownError{Kind: KindRegion, ID: "us-eest", Suggestions: []string{"us-east"}}, want: `unknown Linode region "us-eest", did you mean "us-east"?`},
+		"TwoSuggestions": {err: &UnknownError{Kind: KindRegion, ID: "us-eest", Suggestions: []string{"us-east", "us-west"}}, want: `unknown Linode region "us-eest", did you mean "us-east" or "us-west"?`},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := tc.err.Error(); got != tc.want {
+				t.Errorf("Error(): want %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
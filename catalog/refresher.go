@@ -0,0 +1,73 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package catalog
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/linode/linodego"
+)
+
+// DefaultRefreshInterval is how often a Catalog is refreshed by default.
+const DefaultRefreshInterval = 6 * time.Hour
+
+// refreshTimeout bounds each refresh of a Catalog.
+const refreshTimeout = 1 * time.Minute
+
+// A Refresher refreshes a Catalog from the Linode API when it is started,
+// and then periodically until it is stopped. A Refresher may be added to a
+// controller manager as a Runnable.
+type Refresher struct {
+	Catalog  *Catalog
+	Client   linodego.Client
+	Interval time.Duration
+	Log      logr.Logger
+}
+
+// Start refreshing the Catalog. A Catalog that cannot be refreshed keeps its
+// content until the next refresh.
+func (r *Refresher) Start(stop <-chan struct{}) error {
+	interval := r.Interval
+	if interval <= 0 {
+		interval = DefaultRefreshInterval
+	}
+
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	for {
+		r.refresh()
+		select {
+		case <-stop:
+			return nil
+		case <-t.C:
+		}
+	}
+}
+
+func (r *Refresher) refresh() {
+	ctx, cancel := context.WithTimeout(context.Background(), refreshTimeout)
+	defer cancel()
+
+	if err := r.Catalog.Refresh(ctx, &r.Client); err != nil {
+		r.Log.Error(err, "Cannot refresh catalog, keeping its previous content", "updated", r.Catalog.Updated())
+		return
+	}
+	r.Log.V(1).Info("Refreshed catalog")
+}
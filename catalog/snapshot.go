@@ -0,0 +1,688 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package catalog
+
+// embeddedSnapshot is a snapshot of the Linode catalog taken in October 2019,
+// used to validate managed resources before the catalog is first refreshed
+// and when the controller cannot reach the Linode API.
+const embeddedSnapshot = `{
+  "regions": [
+    {
+      "id": "ap-northeast",
+      "country": "jp"
+    },
+    {
+      "id": "ap-south",
+      "country": "sg"
+    },
+    {
+      "id": "ap-southeast",
+      "country": "au"
+    },
+    {
+      "id": "ap-west",
+      "country": "in"
+    },
+    {
+      "id": "ca-central",
+      "country": "ca"
+    },
+    {
+      "id": "eu-central",
+      "country": "de"
+    },
+    {
+      "id": "eu-west",
+      "country": "uk"
+    },
+    {
+      "id": "us-central",
+      "country": "us"
+    },
+    {
+      "id": "us-east",
+      "country": "us"
+    },
+    {
+      "id": "us-southeast",
+      "country": "us"
+    },
+    {
+      "id": "us-west",
+      "country": "us"
+    }
+  ],
+  "types": [
+    {
+      "id": "g6-nanode-1",
+      "label": "Nanode 1GB",
+      "class": "nanode",
+      "vcpus": 1,
+      "memory": 1024,
+      "disk": 25600,
+      "transfer": 1000,
+      "network_out": 1000,
+      "price": {
+        "hourly": 0.0075,
+        "monthly": 5
+      },
+      "addons": {
+        "backups": {
+          "price": {
+            "hourly": 0.003,
+            "monthly": 2
+          }
+        }
+      }
+    },
+    {
+      "id": "g6-standard-1",
+      "label": "Linode 2GB",
+      "class": "standard",
+      "vcpus": 1,
+      "memory": 2048,
+      "disk": 51200,
+      "transfer": 2000,
+      "network_out": 2000,
+      "price": {
+        "hourly": 0.015,
+        "monthly": 10
+      },
+      "addons": {
+        "backups": {
+          "price": {
+            "hourly": 0.004,
+            "monthly": 2.5
+          }
+        }
+      }
+    },
+    {
+      "id": "g6-standard-2",
+      "label": "Linode 4GB",
+      "class": "standard",
+      "vcpus": 2,
+      "memory": 4096,
+      "disk": 81920,
+      "transfer": 4000,
+      "network_out": 4000,
+      "price": {
+        "hourly": 0.03,
+        "monthly": 20
+      },
+      "addons": {
+        "backups": {
+          "price": {
+            "hourly": 0.008,
+            "monthly": 5
+          }
+        }
+      }
+    },
+    {
+      "id": "g6-standard-4",
+      "label": "Linode 8GB",
+      "class": "standard",
+      "vcpus": 4,
+      "memory": 8192,
+      "disk": 163840,
+      "transfer": 5000,
+      "network_out": 5000,
+      "price": {
+        "hourly": 0.06,
+        "monthly": 40
+      },
+      "addons": {
+        "backups": {
+          "price": {
+            "hourly": 0.015,
+            "monthly": 10
+          }
+        }
+      }
+    },
+    {
+      "id": "g6-standard-6",
+      "label": "Linode 16GB",
+      "class": "standard",
+      "vcpus": 6,
+      "memory": 16384,
+      "disk": 327680,
+      "transfer": 8000,
+      "network_out": 6000,
+      "price": {
+        "hourly": 0.12,
+        "monthly": 80
+      },
+      "addons": {
+        "backups": {
+          "price": {
+            "hourly": 0.03,
+            "monthly": 20
+          }
+        }
+      }
+    },
+    {
+      "id": "g6-standard-8",
+      "label": "Linode 32GB",
+      "class": "standard",
+      "vcpus": 8,
+      "memory": 32768,
+      "disk": 655360,
+      "transfer": 16000,
+      "network_out": 7000,
+      "price": {
+        "hourly": 0.24,
+        "monthly": 160
+      },
+      "addons": {
+        "backups": {
+          "price": {
+            "hourly": 0.06,
+            "monthly": 40
+          }
+        }
+      }
+    },
+    {
+      "id": "g6-standard-16",
+      "label": "Linode 64GB",
+      "class": "standard",
+      "vcpus": 16,
+      "memory": 65536,
+      "disk": 1310720,
+      "transfer": 20000,
+      "network_out": 9000,
+      "price": {
+        "hourly": 0.48,
+        "monthly": 320
+      },
+      "addons": {
+        "backups": {
+          "price": {
+            "hourly": 0.12,
+            "monthly": 80
+          }
+        }
+      }
+    },
+    {
+      "id": "g6-standard-20",
+      "label": "Linode 96GB",
+      "class": "standard",
+      "vcpus": 20,
+      "memory": 98304,
+      "disk": 1966080,
+      "transfer": 20000,
+      "network_out": 10000,
+      "price": {
+        "hourly": 0.72,
+        "monthly": 480
+      },
+      "addons": {
+        "backups": {
+          "price": {
+            "hourly": 0.18,
+            "monthly": 120
+          }
+        }
+      }
+    },
+    {
+      "id": "g6-standard-24",
+      "label": "Linode 128GB",
+      "class": "standard",
+      "vcpus": 24,
+      "memory": 131072,
+      "disk": 2621440,
+      "transfer": 20000,
+      "network_out": 11000,
+      "price": {
+        "hourly": 0.96,
+        "monthly": 640
+      },
+      "addons": {
+        "backups": {
+          "price": {
+            "hourly": 0.24,
+            "monthly": 160
+          }
+        }
+      }
+    },
+    {
+      "id": "g6-standard-32",
+      "label": "Linode 192GB",
+      "class": "standard",
+      "vcpus": 32,
+      "memory": 196608,
+      "disk": 3932160,
+      "transfer": 20000,
+      "network_out": 12000,
+      "price": {
+        "hourly": 1.44,
+        "monthly": 960
+      },
+      "addons": {
+        "backups": {
+          "price": {
+            "hourly": 0.36,
+            "monthly": 240
+          }
+        }
+      }
+    },
+    {
+      "id": "g6-highmem-1",
+      "label": "Linode 24GB",
+      "class": "highmem",
+      "vcpus": 1,
+      "memory": 24576,
+      "disk": 20480,
+      "transfer": 5000,
+      "network_out": 5000,
+      "price": {
+        "hourly": 0.09,
+        "monthly": 60
+      },
+      "addons": {
+        "backups": {
+          "price": {
+            "hourly": 0.0075,
+            "monthly": 5
+          }
+        }
+      }
+    },
+    {
+      "id": "g6-highmem-2",
+      "label": "Linode 48GB",
+      "class": "highmem",
+      "vcpus": 2,
+      "memory": 49152,
+      "disk": 40960,
+      "transfer": 6000,
+      "network_out": 6000,
+      "price": {
+        "hourly": 0.18,
+        "monthly": 120
+      },
+      "addons": {
+        "backups": {
+          "price": {
+            "hourly": 0.015,
+            "monthly": 10
+          }
+        }
+      }
+    },
+    {
+      "id": "g6-highmem-4",
+      "label": "Linode 90GB",
+      "class": "highmem",
+      "vcpus": 4,
+      "memory": 92160,
+      "disk": 92160,
+      "transfer": 7000,
+      "network_out": 7000,
+      "price": {
+        "hourly": 0.36,
+        "monthly": 240
+      },
+      "addons": {
+        "backups": {
+          "price": {
+            "hourly": 0.03,
+            "monthly": 20
+          }
+        }
+      }
+    },
+    {
+      "id": "g6-highmem-8",
+      "label": "Linode 150GB",
+      "class": "highmem",
+      "vcpus": 8,
+      "memory": 153600,
+      "disk": 204800,
+      "transfer": 8000,
+      "network_out": 8000,
+      "price": {
+        "hourly": 0.72,
+        "monthly": 480
+      },
+      "addons": {
+        "backups": {
+          "price": {
+            "hourly": 0.06,
+            "monthly": 40
+          }
+        }
+      }
+    },
+    {
+      "id": "g6-highmem-16",
+      "label": "Linode 300GB",
+      "class": "highmem",
+      "vcpus": 16,
+      "memory": 307200,
+      "disk": 348160,
+      "transfer": 9000,
+      "network_out": 9000,
+      "price": {
+        "hourly": 1.44,
+        "monthly": 960
+      },
+      "addons": {
+        "backups": {
+          "price": {
+            "hourly": 0.12,
+            "monthly": 80
+          }
+        }
+      }
+    },
+    {
+      "id": "g6-dedicated-2",
+      "label": "Dedicated 4GB",
+      "class": "dedicated",
+      "vcpus": 2,
+      "memory": 4096,
+      "disk": 81920,
+      "transfer": 4000,
+      "network_out": 4000,
+      "price": {
+        "hourly": 0.045,
+        "monthly": 30
+      },
+      "addons": {
+        "backups": {
+          "price": {
+            "hourly": 0.008,
+            "monthly": 5
+          }
+        }
+      }
+    },
+    {
+      "id": "g6-dedicated-4",
+      "label": "Dedicated 8GB",
+      "class": "dedicated",
+      "vcpus": 4,
+      "memory": 8192,
+      "disk": 163840,
+      "transfer": 5000,
+      "network_out": 5000,
+      "price": {
+        "hourly": 0.09,
+        "monthly": 60
+      },
+      "addons": {
+        "backups": {
+          "price": {
+            "hourly": 0.015,
+            "monthly": 10
+          }
+        }
+      }
+    },
+    {
+      "id": "g6-dedicated-8",
+      "label": "Dedicated 16GB",
+      "class": "dedicated",
+      "vcpus": 8,
+      "memory": 16384,
+      "disk": 327680,
+      "transfer": 6000,
+      "network_out": 6000,
+      "price": {
+        "hourly": 0.18,
+        "monthly": 120
+      },
+      "addons": {
+        "backups": {
+          "price": {
+            "hourly": 0.03,
+            "monthly": 20
+          }
+        }
+      }
+    },
+    {
+      "id": "g6-dedicated-16",
+      "label": "Dedicated 32GB",
+      "class": "dedicated",
+      "vcpus": 16,
+      "memory": 32768,
+      "disk": 655360,
+      "transfer": 7000,
+      "network_out": 7000,
+      "price": {
+        "hourly": 0.36,
+        "monthly": 240
+      },
+      "addons": {
+        "backups": {
+          "price": {
+            "hourly": 0.06,
+            "monthly": 40
+          }
+        }
+      }
+    },
+    {
+      "id": "g6-dedicated-32",
+      "label": "Dedicated 64GB",
+      "class": "dedicated",
+      "vcpus": 32,
+      "memory": 65536,
+      "disk": 1310720,
+      "transfer": 8000,
+      "network_out": 8000,
+      "price": {
+        "hourly": 0.72,
+        "monthly": 480
+      },
+      "addons": {
+        "backups": {
+          "price": {
+            "hourly": 0.12,
+            "monthly": 80
+          }
+        }
+      }
+    },
+    {
+      "id": "g6-dedicated-48",
+      "label": "Dedicated 96GB",
+      "class": "dedicated",
+      "vcpus": 48,
+      "memory": 98304,
+      "disk": 1966080,
+      "transfer": 9000,
+      "network_out": 9000,
+      "price": {
+        "hourly": 1.08,
+        "monthly": 720
+      },
+      "addons": {
+        "backups": {
+          "price": {
+            "hourly": 0.18,
+            "monthly": 120
+          }
+        }
+      }
+    }
+  ],
+  "images": [
+    {
+      "id": "linode/alpine3.9",
+      "label": "Alpine 3.9",
+      "vendor": "Alpine",
+      "type": "manual",
+      "is_public": true,
+      "deprecated": false
+    },
+    {
+      "id": "linode/alpine3.10",
+      "label": "Alpine 3.10",
+      "vendor": "Alpine",
+      "type": "manual",
+      "is_public": true,
+      "deprecated": false
+    },
+    {
+      "id": "linode/arch",
+      "label": "Arch Linux",
+      "vendor": "Arch",
+      "type": "manual",
+      "is_public": true,
+      "deprecated": false
+    },
+    {
+      "id": "linode/centos7",
+      "label": "CentOS 7",
+      "vendor": "CentOS",
+      "type": "manual",
+      "is_public": true,
+      "deprecated": false
+    },
+    {
+      "id": "linode/centos8",
+      "label": "CentOS 8",
+      "vendor": "CentOS",
+      "type": "manual",
+      "is_public": true,
+      "deprecated": false
+    },
+    {
+      "id": "linode/containerlinux",
+      "label": "Container Linux",
+      "vendor": "CoreOS",
+      "type": "manual",
+      "is_public": true,
+      "deprecated": false
+    },
+    {
+      "id": "linode/debian8",
+      "label": "Debian 8",
+      "vendor": "Debian",
+      "type": "manual",
+      "is_public": true,
+      "deprecated": true
+    },
+    {
+      "id": "linode/debian9",
+      "label": "Debian 9",
+      "vendor": "Debian",
+      "type": "manual",
+      "is_public": true,
+      "deprecated": false
+    },
+    {
+      "id": "linode/debian10",
+      "label": "Debian 10",
+      "vendor": "Debian",
+      "type": "manual",
+      "is_public": true,
+      "deprecated": false
+    },
+    {
+      "id": "linode/fedora29",
+      "label": "Fedora 29",
+      "vendor": "Fedora",
+      "type": "manual",
+      "is_public": true,
+      "deprecated": true
+    },
+    {
+      "id": "linode/fedora30",
+      "label": "Fedora 30",
+      "vendor": "Fedora",
+      "type": "manual",
+      "is_public": true,
+      "deprecated": false
+    },
+    {
+      "id": "linode/fedora31",
+      "label": "Fedora 31",
+      "vendor": "Fedora",
+      "type": "manual",
+      "is_public": true,
+      "deprecated": false
+    },
+    {
+      "id": "linode/gentoo",
+      "label": "Gentoo",
+      "vendor": "Gentoo",
+      "type": "manual",
+      "is_public": true,
+      "deprecated": false
+    },
+    {
+      "id": "linode/opensuse15.1",
+      "label": "openSUSE Leap 15.1",
+      "vendor": "openSUSE",
+      "type": "manual",
+      "is_public": true,
+      "deprecated": false
+    },
+    {
+      "id": "linode/slackware14.2",
+      "label": "Slackware 14.2",
+      "vendor": "Slackware",
+      "type": "manual",
+      "is_public": true,
+      "deprecated": false
+    },
+    {
+      "id": "linode/ubuntu16.04lts",
+      "label": "Ubuntu 16.04 LTS",
+      "vendor": "Ubuntu",
+      "type": "manual",
+      "is_public": true,
+      "deprecated": false
+    },
+    {
+      "id": "linode/ubuntu18.04",
+      "label": "Ubuntu 18.04 LTS",
+      "vendor": "Ubuntu",
+      "type": "manual",
+      "is_public": true,
+      "deprecated": false
+    },
+    {
+      "id": "linode/ubuntu19.04",
+      "label": "Ubuntu 19.04",
+      "vendor": "Ubuntu",
+      "type": "manual",
+      "is_public": true,
+      "deprecated": false
+    },
+    {
+      "id": "linode/ubuntu19.10",
+      "label": "Ubuntu 19.10",
+      "vendor": "Ubuntu",
+      "type": "manual",
+      "is_public": true,
+      "deprecated": false
+    }
+  ]
+}
+`
@@ -0,0 +1,70 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/linode/linodego"
+)
+
+// ImageStatus constants include the Linode API Image status values
+const (
+	ImageCreating      = "creating"
+	ImagePendingUpload = "pending_upload"
+	ImageAvailable     = "available"
+)
+
+// Image is a Linode Image including its status, which linodego.Image does
+// not support.
+type Image struct {
+	linodego.Image
+	Status string `json:"status"`
+}
+
+// ImageUploadCreateOptions are the options used to create an Image that is
+// uploaded to the Linode API.
+type ImageUploadCreateOptions struct {
+	Region      string `json:"region"`
+	Label       string `json:"label"`
+	Description string `json:"description,omitempty"`
+}
+
+// ImageUpload is the response to creating an Image that is uploaded to the
+// Linode API. The image is uploaded with an HTTP PUT to UploadTo.
+type ImageUpload struct {
+	Image    Image  `json:"image"`
+	UploadTo string `json:"upload_to"`
+}
+
+// GetImage gets a Linode Image including its status.
+func GetImage(ctx context.Context, client *linodego.Client, id string) (*Image, error) {
+	e := fmt.Sprintf("images/%s", id)
+	r, err := client.R(ctx).SetResult(&Image{}).Get(e)
+	if err = apiError(r, err); err != nil {
+		return nil, err
+	}
+	return r.Result().(*Image), nil
+}
+
+// CreateImageUpload creates a Linode Image that is pending upload, and returns
+// the URL the image must be uploaded to.
+func CreateImageUpload(ctx context.Context, client *linodego.Client, opts ImageUploadCreateOptions) (*ImageUpload, error) {
+	r, err := client.R(ctx).SetResult(&ImageUpload{}).SetBody(opts).Post("images/upload")
+	if err = apiError(r, err); err != nil {
+		return nil, err
+	}
+	return r.Result().(*ImageUpload), nil
+}
@@ -0,0 +1,129 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/linode/linodego"
+)
+
+// ActionLinodeMigrateDatacenter is the Linode Event action of a cross-region
+// Instance migration.
+const ActionLinodeMigrateDatacenter linodego.EventAction = "linode_migrate_datacenter"
+
+// MigrateInstance initiates the migration of a Linode Instance to the supplied
+// region. linodego.Client.MigrateInstance does not accept a region.
+func MigrateInstance(ctx context.Context, client *linodego.Client, linodeID int, region string) error {
+	body := map[string]string{"region": region}
+	e := fmt.Sprintf("linode/instances/%d/migrate", linodeID)
+	return apiError(client.R(ctx).SetBody(body).Post(e))
+}
+
+// InstanceBackupSchedule is the schedule of Linode Instance Backups.
+// linodego.InstanceBackup does not serialize its schedule with the key
+// expected by the Linode API.
+type InstanceBackupSchedule struct {
+	Day    string `json:"day,omitempty"`
+	Window string `json:"window,omitempty"`
+}
+
+// UpdateInstanceBackupSchedule sets the Backup schedule of a Linode Instance.
+func UpdateInstanceBackupSchedule(ctx context.Context, client *linodego.Client, linodeID int, schedule InstanceBackupSchedule) error {
+	body := map[string]interface{}{
+		"backups": map[string]interface{}{"schedule": schedule},
+	}
+	e := fmt.Sprintf("linode/instances/%d", linodeID)
+	return apiError(client.R(ctx).SetBody(body).Put(e))
+}
+
+// LatestInstanceEvent returns the most recent Event with the supplied action
+// for a Linode Instance, or nil if there is none.
+func LatestInstanceEvent(ctx context.Context, client *linodego.Client, linodeID int, action linodego.EventAction) (*linodego.Event, error) {
+	filter, err := json.Marshal(map[string]interface{}{
+		"entity.id":   linodeID,
+		"entity.type": "linode",
+		"action":      action,
+		"+order_by":   "created",
+		"+order":      "desc",
+	})
+	if err != nil {
+		return nil, err
+	}
+	events, err := client.ListEvents(ctx, linodego.NewListOptions(1, string(filter)))
+	if err != nil || len(events) == 0 {
+		return nil, err
+	}
+	return &events[0], nil
+}
+
+// InstanceConfigInterface is a network interface of a Linode Instance Config.
+type InstanceConfigInterface struct {
+	Purpose     string `json:"purpose"`
+	Label       string `json:"label,omitempty"`
+	IPAMAddress string `json:"ipam_address,omitempty"`
+}
+
+// InstanceConfig is a Linode Instance Config including its network
+// interfaces, which linodego.InstanceConfig does not support.
+type InstanceConfig struct {
+	linodego.InstanceConfig
+	Interfaces []InstanceConfigInterface `json:"interfaces"`
+}
+
+// GetInstanceConfig gets a Linode Instance Config including its network
+// interfaces.
+func GetInstanceConfig(ctx context.Context, client *linodego.Client, linodeID, configID int) (*InstanceConfig, error) {
+	e := fmt.Sprintf("linode/instances/%d/configs/%d", linodeID, configID)
+	r, err := client.R(ctx).SetResult(&InstanceConfig{}).Get(e)
+	if err = apiError(r, err); err != nil {
+		return nil, err
+	}
+	return r.Result().(*InstanceConfig), nil
+}
+
+// CreateInstanceConfig creates a Linode Instance Config with the supplied
+// network interfaces.
+func CreateInstanceConfig(ctx context.Context, client *linodego.Client, linodeID int, opts linodego.InstanceConfigCreateOptions, interfaces []InstanceConfigInterface) (*InstanceConfig, error) {
+	body := struct {
+		linodego.InstanceConfigCreateOptions
+		Interfaces []InstanceConfigInterface `json:"interfaces,omitempty"`
+	}{opts, interfaces}
+	e := fmt.Sprintf("linode/instances/%d/configs", linodeID)
+	r, err := client.R(ctx).SetResult(&InstanceConfig{}).SetBody(body).Post(e)
+	if err = apiError(r, err); err != nil {
+		return nil, err
+	}
+	return r.Result().(*InstanceConfig), nil
+}
+
+// UpdateInstanceConfig updates a Linode Instance Config, replacing its
+// network interfaces.
+func UpdateInstanceConfig(ctx context.Context, client *linodego.Client, linodeID, configID int, opts linodego.InstanceConfigUpdateOptions, interfaces []InstanceConfigInterface) (*InstanceConfig, error) {
+	if interfaces == nil {
+		interfaces = []InstanceConfigInterface{}
+	}
+	body := struct {
+		linodego.InstanceConfigUpdateOptions
+		Interfaces []InstanceConfigInterface `json:"interfaces"`
+	}{opts, interfaces}
+	e := fmt.Sprintf("linode/instances/%d/configs/%d", linodeID, configID)
+	r, err := client.R(ctx).SetResult(&InstanceConfig{}).SetBody(body).Put(e)
+	if err = apiError(r, err); err != nil {
+		return nil, err
+	}
+	return r.Result().(*InstanceConfig), nil
+}
@@ -13,35 +13,102 @@ limitations under the License.
 package clients
 
 import (
-	"log"
 	"net/http"
-	"os"
 	"strings"
 
 	"github.com/linode/linodego"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"golang.org/x/oauth2"
+	"golang.org/x/time/rate"
+	"gopkg.in/resty.v1"
 )
 
-// NewClient returns a new Client
-func NewClient(credentials []byte) linodego.Client {
-	var apiKey string
-	if credentials == nil {
-		var ok bool
-		apiKey, ok = os.LookupEnv("LINODE_TOKEN")
-		if !ok {
-			log.Fatal("No credentials provided and LINODE_TOKEN not set.")
-		}
-	} else {
-		apiKey = strings.TrimSpace(string(credentials))
+// Requests made by a Client are rate limited client side, so that a Client
+// shared by many managed resources stays well below the Linode API limits.
+const (
+	DefaultRateLimit = 10
+	DefaultRateBurst = 20
+)
+
+// NewClient returns a new Client using the supplied token credentials. The
+// Client is safe for concurrent use; its requests share a single connection
+// pool and rate limiter.
+func NewClient(credentials []byte) (linodego.Client, error) {
+	apiKey := strings.TrimSpace(string(credentials))
+	if apiKey == "" {
+		return linodego.Client{}, errors.New("no credentials provided")
 	}
 	tokenSource := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: apiKey})
 	oauth2Client := &http.Client{
 		Transport: &oauth2.Transport{
 			Source: tokenSource,
+			Base: newTracingTransport(&rateLimitedTransport{
+				base:    &instrumentedTransport{base: http.DefaultTransport},
+				limiter: rate.NewLimiter(DefaultRateLimit, DefaultRateBurst),
+			}),
 		},
 	}
 
 	client := linodego.NewClient(oauth2Client)
 
-	return client
+	return client, nil
+}
+
+// NewPublicClient returns a new Client without credentials, which may only
+// make requests to public endpoints of the Linode API such as regions, types
+// and public images.
+func NewPublicClient() linodego.Client {
+	return linodego.NewClient(&http.Client{
+		Transport: newTracingTransport(&rateLimitedTransport{
+			base:    &instrumentedTransport{base: http.DefaultTransport},
+			limiter: rate.NewLimiter(DefaultRateLimit, DefaultRateBurst),
+		}),
+	})
+}
+
+// newTracingTransport returns a transport that records a span for each Linode
+// API request, as a child of the span carried by the request context, if any.
+// The span includes the time spent waiting for the rate limiter.
+func newTracingTransport(base http.RoundTripper) http.RoundTripper {
+	return otelhttp.NewTransport(base, otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+		return r.Method + " " + apiEndpoint(r.URL.Path)
+	}))
+}
+
+// A rateLimitedTransport waits for its limiter before each request.
+type rateLimitedTransport struct {
+	base    http.RoundTripper
+	limiter *rate.Limiter
+}
+
+func (t *rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+	return t.base.RoundTrip(req)
+}
+
+// apiError converts the result of a raw Linode API request into a
+// *linodego.Error, matching the errors returned by the linodego.Client
+// methods. It returns nil for successful responses.
+func apiError(r *resty.Response, err error) error {
+	if err != nil {
+		return linodego.NewError(err)
+	}
+	if !r.IsError() {
+		return nil
+	}
+	msg := r.Status()
+	if e, ok := r.Error().(*linodego.APIError); ok && len(e.Errors) > 0 {
+		msg = e.Error()
+	}
+	return &linodego.Error{Response: r.RawResponse, Code: r.StatusCode(), Message: msg}
+}
+
+// IsNotFound returns true if the supplied error indicates that a Linode API
+// resource was not found.
+func IsNotFound(err error) bool {
+	e, ok := err.(*linodego.Error)
+	return ok && e.Code == http.StatusNotFound
 }
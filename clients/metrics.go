@@ -0,0 +1,118 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/displague/stack-linode/logging"
+)
+
+// HeaderRateLimitRemaining is the Linode API response header holding the
+// number of requests remaining in the current rate limit window.
+const HeaderRateLimitRemaining = "X-RateLimit-Remaining"
+
+// HeaderRequestID is the response header identifying a Linode API request,
+// which is logged to correlate requests with Linode support.
+const HeaderRequestID = "X-Request-Id"
+
+var apiLog = ctrl.Log.WithName("linode.api")
+
+var (
+	apiRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "linode_api_requests_total",
+		Help: "Total number of Linode API requests by endpoint, method and status code.",
+	}, []string{"endpoint", "method", "code"})
+
+	apiRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "linode_api_request_duration_seconds",
+		Help:    "Latency of Linode API requests by endpoint and method.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"endpoint", "method"})
+
+	apiRateLimited = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "linode_api_rate_limited_total",
+		Help: "Total number of Linode API requests rejected with 429 Too Many Requests by endpoint.",
+	}, []string{"endpoint"})
+
+	apiRateLimitRemaining = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "linode_api_rate_limit_remaining",
+		Help: "Linode API requests remaining in the current rate limit window, as last reported by endpoint.",
+	}, []string{"endpoint"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(apiRequests, apiRequestDuration, apiRateLimited, apiRateLimitRemaining)
+}
+
+// An instrumentedTransport records metrics for each Linode API request, and
+// logs it at debug level using the logger of the request context, if any.
+type instrumentedTransport struct {
+	base http.RoundTripper
+}
+
+func (t *instrumentedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	endpoint := apiEndpoint(req.URL.Path)
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	duration := time.Since(start)
+	apiRequestDuration.WithLabelValues(endpoint, req.Method).Observe(duration.Seconds())
+
+	log := apiLog
+	if l, ok := logging.FromContext(req.Context()); ok {
+		log = l
+	}
+
+	if err != nil {
+		apiRequests.WithLabelValues(endpoint, req.Method, "error").Inc()
+		log.V(1).Info("Linode API request failed", "method", req.Method, "endpoint", endpoint, "duration", duration.String(), "error", err.Error())
+		return resp, err
+	}
+
+	code := strconv.Itoa(resp.StatusCode)
+	apiRequests.WithLabelValues(endpoint, req.Method, code).Inc()
+	log.V(1).Info("Linode API request", "method", req.Method, "endpoint", endpoint, "code", code, "duration", duration.String(), "requestId", resp.Header.Get(HeaderRequestID))
+	if resp.StatusCode == http.StatusTooManyRequests {
+		apiRateLimited.WithLabelValues(endpoint).Inc()
+	}
+	if remaining, err := strconv.Atoi(resp.Header.Get(HeaderRateLimitRemaining)); err == nil {
+		apiRateLimitRemaining.WithLabelValues(endpoint).Set(float64(remaining))
+	}
+	return resp, nil
+}
+
+var (
+	apiVersionPrefix = regexp.MustCompile(`^/v[0-9]+(beta)?/`)
+	apiNumericID     = regexp.MustCompile(`^[0-9]+$`)
+)
+
+// apiEndpoint returns the path of a Linode API request with its version prefix
+// removed and numeric IDs replaced by ":id", so that metrics are not labelled
+// by the ID of every resource.
+func apiEndpoint(path string) string {
+	segments := strings.Split(strings.Trim(apiVersionPrefix.ReplaceAllString(path, "/"), "/"), "/")
+	for i, s := range segments {
+		if apiNumericID.MatchString(s) {
+			segments[i] = ":id"
+		}
+	}
+	return strings.Join(segments, "/")
+}
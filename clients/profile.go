@@ -0,0 +1,96 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"strings"
+
+	"github.com/linode/linodego"
+)
+
+// headerOAuthScopes is the Linode API response header listing the OAuth
+// scopes of the token used to make the request.
+const headerOAuthScopes = "X-OAuth-Scopes"
+
+// scopeAll is the OAuth scope granting full access to the Linode API.
+const scopeAll = "*"
+
+// tokenPrefixLength is the number of leading characters of a Personal Access
+// Token that the Linode API returns when listing tokens.
+const tokenPrefixLength = 16
+
+// GetProfileScopes gets the Linode profile of the user whose token the client
+// uses, along with the OAuth scopes of that token.
+func GetProfileScopes(ctx context.Context, client *linodego.Client) (*linodego.Profile, string, error) {
+	r, err := client.R(ctx).SetResult(&linodego.Profile{}).Get("profile")
+	if err = apiError(r, err); err != nil {
+		return nil, "", err
+	}
+	return r.Result().(*linodego.Profile), r.Header().Get(headerOAuthScopes), nil
+}
+
+// FindToken returns the Personal Access Token of the supplied token string, or
+// nil if it is not a Personal Access Token of the client's user.
+func FindToken(ctx context.Context, client *linodego.Client, token string) (*linodego.Token, error) {
+	if len(token) < tokenPrefixLength {
+		return nil, nil
+	}
+	tokens, err := client.ListTokens(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	for i := range tokens {
+		if strings.HasPrefix(token, tokens[i].Token) && len(tokens[i].Token) >= tokenPrefixLength {
+			return &tokens[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// MissingScopes returns the required OAuth scopes, such as
+// "linodes:read_write", that the supplied space separated scopes do not
+// satisfy. A read_write scope satisfies the read_only scope of the same name.
+func MissingScopes(scopes string, required []string) []string {
+	have := map[string]string{}
+	for _, s := range strings.Fields(scopes) {
+		if s == scopeAll {
+			return nil
+		}
+		name, access := splitScope(s)
+		if have[name] != "read_write" {
+			have[name] = access
+		}
+	}
+
+	var missing []string
+	for _, r := range required {
+		name, access := splitScope(r)
+		switch have[name] {
+		case "read_write":
+		case access:
+		default:
+			missing = append(missing, r)
+		}
+	}
+	return missing
+}
+
+func splitScope(scope string) (name, access string) {
+	parts := strings.SplitN(scope, ":", 2)
+	if len(parts) < 2 {
+		return parts[0], ""
+	}
+	return parts[0], parts[1]
+}
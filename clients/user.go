@@ -0,0 +1,131 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/linode/linodego"
+)
+
+// GrantEntityType is the type of a Linode entity a restricted User may be
+// granted access to.
+type GrantEntityType string
+
+// GrantEntityType constants include the Linode API entity types of User Grants
+const (
+	GrantEntityLinode       GrantEntityType = "linode"
+	GrantEntityDomain       GrantEntityType = "domain"
+	GrantEntityNodeBalancer GrantEntityType = "nodebalancer"
+	GrantEntityImage        GrantEntityType = "image"
+	GrantEntityLongview     GrantEntityType = "longview"
+	GrantEntityStackScript  GrantEntityType = "stackscript"
+	GrantEntityVolume       GrantEntityType = "volume"
+)
+
+// GrantEntityTypes lists every GrantEntityType.
+var GrantEntityTypes = []GrantEntityType{
+	GrantEntityLinode,
+	GrantEntityDomain,
+	GrantEntityNodeBalancer,
+	GrantEntityImage,
+	GrantEntityLongview,
+	GrantEntityStackScript,
+	GrantEntityVolume,
+}
+
+// GlobalUserGrants are the account-wide grants of a restricted Linode User.
+// AccountAccess is nil when the User has no access to the Account.
+type GlobalUserGrants struct {
+	AccountAccess        *string `json:"account_access"`
+	AddDomains           bool    `json:"add_domains"`
+	AddImages            bool    `json:"add_images"`
+	AddLinodes           bool    `json:"add_linodes"`
+	AddLongview          bool    `json:"add_longview"`
+	AddNodeBalancers     bool    `json:"add_nodebalancers"`
+	AddStackScripts      bool    `json:"add_stackscripts"`
+	AddVolumes           bool    `json:"add_volumes"`
+	CancelAccount        bool    `json:"cancel_account"`
+	LongviewSubscription bool    `json:"longview_subscription"`
+}
+
+// EntityUserGrant is the grant of a restricted Linode User to a single
+// entity. Permissions is nil when the User has no access to the entity.
+type EntityUserGrant struct {
+	ID          int     `json:"id"`
+	Label       string  `json:"label,omitempty"`
+	Permissions *string `json:"permissions"`
+}
+
+// UserGrants are the grants of a restricted Linode User. linodego does not
+// support User Grants.
+type UserGrants struct {
+	Global   *GlobalUserGrants                     `json:"global,omitempty"`
+	Entities map[GrantEntityType][]EntityUserGrant `json:"-"`
+}
+
+// grantsBody returns the Linode API representation of the grants.
+func (g UserGrants) grantsBody() map[string]interface{} {
+	body := map[string]interface{}{}
+	if g.Global != nil {
+		body["global"] = g.Global
+	}
+	for t, grants := range g.Entities {
+		body[string(t)] = grants
+	}
+	return body
+}
+
+// userGrantsResponse is the Linode API representation of UserGrants.
+type userGrantsResponse struct {
+	Global       *GlobalUserGrants `json:"global"`
+	Linode       []EntityUserGrant `json:"linode"`
+	Domain       []EntityUserGrant `json:"domain"`
+	NodeBalancer []EntityUserGrant `json:"nodebalancer"`
+	Image        []EntityUserGrant `json:"image"`
+	Longview     []EntityUserGrant `json:"longview"`
+	StackScript  []EntityUserGrant `json:"stackscript"`
+	Volume       []EntityUserGrant `json:"volume"`
+}
+
+// GetUserGrants gets the grants of a restricted Linode User.
+func GetUserGrants(ctx context.Context, client *linodego.Client, username string) (*UserGrants, error) {
+	e := fmt.Sprintf("account/users/%s/grants", username)
+	r, err := client.R(ctx).SetResult(&userGrantsResponse{}).Get(e)
+	if err = apiError(r, err); err != nil {
+		return nil, err
+	}
+	g := r.Result().(*userGrantsResponse)
+	return &UserGrants{
+		Global: g.Global,
+		Entities: map[GrantEntityType][]EntityUserGrant{
+			GrantEntityLinode:       g.Linode,
+			GrantEntityDomain:       g.Domain,
+			GrantEntityNodeBalancer: g.NodeBalancer,
+			GrantEntityImage:        g.Image,
+			GrantEntityLongview:     g.Longview,
+			GrantEntityStackScript:  g.StackScript,
+			GrantEntityVolume:       g.Volume,
+		},
+	}, nil
+}
+
+// UpdateUserGrants updates the grants of a restricted Linode User. Global
+// grants are left unchanged when they are nil, as are the grants of entities
+// that are not included.
+func UpdateUserGrants(ctx context.Context, client *linodego.Client, username string, grants UserGrants) error {
+	e := fmt.Sprintf("account/users/%s/grants", username)
+	return apiError(client.R(ctx).SetBody(grants.grantsBody()).Put(e))
+}
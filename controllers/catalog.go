@@ -0,0 +1,90 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"strconv"
+
+	linodev1alpha1 "github.com/displague/stack-linode/api/v1alpha1"
+	"github.com/displague/stack-linode/catalog"
+)
+
+// validateInstance validates the region, type and image of the supplied
+// Instance against the supplied catalog, which may be nil.
+func validateInstance(c *catalog.Catalog, m *linodev1alpha1.Instance) error {
+	if err := validateRegion(c, m.Spec.Region); err != nil {
+		return err
+	}
+	if err := validateType(c, m.Spec.Type); err != nil {
+		return err
+	}
+	return validateImage(c, m.Spec.Image)
+}
+
+func validateRegion(c *catalog.Catalog, region string) error {
+	if c == nil {
+		return nil
+	}
+	return c.ValidateRegion(region)
+}
+
+func validateType(c *catalog.Catalog, typ string) error {
+	if c == nil {
+		return nil
+	}
+	return c.ValidateType(typ)
+}
+
+func validateImage(c *catalog.Catalog, image string) error {
+	if c == nil || image == "" {
+		return nil
+	}
+	return c.ValidateImage(image)
+}
+
+// instanceMonthlyCost returns the estimated monthly cost of an Instance of the
+// supplied type, or an empty string if it cannot be estimated.
+func instanceMonthlyCost(c *catalog.Catalog, typ string) string {
+	if c == nil {
+		return ""
+	}
+	cost, ok := c.MonthlyCost(typ)
+	if !ok {
+		return ""
+	}
+	return formatCost(cost)
+}
+
+// backupsMonthlyCost returns the estimated monthly cost of the Backups of an
+// Instance of the supplied type, or an empty string if it cannot be
+// estimated.
+func backupsMonthlyCost(c *catalog.Catalog, typ string) string {
+	if c == nil {
+		return ""
+	}
+	cost, ok := c.BackupsMonthlyCost(typ)
+	if !ok {
+		return ""
+	}
+	return formatCost(cost)
+}
+
+// formatCost formats a cost in US dollars with two decimal places, such as
+// 10.00.
+func formatCost(cost float64) string {
+	return strconv.FormatFloat(cost, 'f', 2, 64)
+}
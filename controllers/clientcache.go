@@ -0,0 +1,96 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"sync"
+
+	"github.com/linode/linodego"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/displague/stack-linode/clients"
+)
+
+// providerClients is shared by the controllers of all managed resources, so
+// that every resource referencing a Provider uses the same Linode API client,
+// connection pool and rate limiter.
+var providerClients = newClientCache()
+
+// A clientOwner identifies the Provider or ProviderConfig a client was created
+// for, so that its client can be removed once it no longer exists.
+type clientOwner struct {
+	kind string
+	name types.NamespacedName
+}
+
+type cachedClient struct {
+	owner   clientOwner
+	version string
+	client  linodego.Client
+}
+
+// A clientCache holds one Linode API client per Provider, keyed by the UID of
+// the Provider and the version of its credentials.
+type clientCache struct {
+	mu      sync.Mutex
+	clients map[types.UID]cachedClient
+}
+
+func newClientCache() *clientCache {
+	return &clientCache{clients: map[types.UID]cachedClient{}}
+}
+
+// Get returns the client cached for the supplied Provider UID and credentials
+// version, creating it from the supplied credentials if necessary. A client
+// created for an older version of the credentials is replaced.
+func (c *clientCache) Get(uid types.UID, owner clientOwner, version string, credentials []byte) (linodego.Client, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if cc, ok := c.clients[uid]; ok && cc.version == version {
+		return cc.client, nil
+	}
+
+	client, err := clients.NewClient(credentials)
+	if err != nil {
+		return linodego.Client{}, err
+	}
+	c.clients[uid] = cachedClient{owner: owner, version: version, client: client}
+	return client, nil
+}
+
+// Remove the client cached for the supplied Provider UID, if any.
+func (c *clientCache) Remove(uid types.UID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.clients, uid)
+}
+
+// RemoveOwner removes any client cached for the supplied Provider or
+// ProviderConfig. It is used once its UID can no longer be read, because it
+// has been deleted.
+func (c *clientCache) RemoveOwner(owner clientOwner) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for uid, cc := range c.clients {
+		if cc.owner == owner {
+			delete(c.clients, uid)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestClientCacheRemoveOwner(t *testing.T) {
+	deleted := clientOwner{kind: "Provider", name: types.NamespacedName{Namespace: "default", Name: "deleted"}}
+	live := clientOwner{kind: "Provider", name: types.NamespacedName{Namespace: "default", Name: "live"}}
+
+	c := newClientCache()
+	if _, err := c.Get("deleted-uid", deleted, "1", []byte("token")); err != nil {
+		t.Fatalf("Get(): %v", err)
+	}
+	if _, err := c.Get("live-uid", live, "1", []byte("token")); err != nil {
+		t.Fatalf("Get(): %v", err)
+	}
+
+	c.RemoveOwner(deleted)
+
+	if _, ok := c.clients["deleted-uid"]; ok {
+		t.Errorf("RemoveOwner(%v): client of deleted owner is still cached", deleted)
+	}
+	if _, ok := c.clients["live-uid"]; !ok {
+		t.Errorf("RemoveOwner(%v): client of another owner was removed", deleted)
+	}
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/linode/linodego"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplaneio/crossplane-runtime/pkg/meta"
+
+	linodev1alpha1 "github.com/displague/stack-linode/api/v1alpha1"
+)
+
+const errNoProviderRef = "managed resource does not reference a Provider or ProviderConfig"
+
+// A providerReferencer is a managed resource that references the Provider or
+// ProviderConfig whose credentials are used to connect to the Linode API.
+type providerReferencer interface {
+	metav1.Object
+	runtime.Object
+	GetProviderReference() *corev1.ObjectReference
+}
+
+// connectLinode returns a Linode API client using the credentials of the
+// Provider or ProviderConfig referenced by the supplied managed resource.
+// Clients are shared by all managed resources that reference the same Provider
+// or ProviderConfig, unless a newClientFn is supplied.
+func connectLinode(ctx context.Context, kube client.Client, mg providerReferencer, newClientFn func(credentials []byte) (linodego.Client, error)) (linodego.Client, error) {
+	ref := mg.GetProviderReference()
+	if ref == nil {
+		return linodego.Client{}, errors.New(errNoProviderRef)
+	}
+
+	var (
+		uid         types.UID
+		owner       clientOwner
+		credentials []byte
+		version     string
+		err         error
+	)
+	switch ref.Kind {
+	case linodev1alpha1.ProviderConfigKind:
+		pc := &linodev1alpha1.ProviderConfig{}
+		if err := kube.Get(ctx, types.NamespacedName{Name: ref.Name}, pc); err != nil {
+			return linodego.Client{}, errors.Wrapf(err, "cannot get provider config %s", ref.Name)
+		}
+		if err := trackProviderConfigUsage(ctx, kube, pc, mg); err != nil {
+			return linodego.Client{}, err
+		}
+		uid = pc.GetUID()
+		owner = clientOwner{kind: linodev1alpha1.ProviderConfigKind, name: types.NamespacedName{Name: ref.Name}}
+		credentials, version, err = getProviderConfigCredentials(ctx, kube, pc)
+	default:
+		p := &linodev1alpha1.Provider{}
+		n := meta.NamespacedNameOf(ref)
+		if err := kube.Get(ctx, n, p); err != nil {
+			return linodego.Client{}, errors.Wrapf(err, "cannot get provider %s", n)
+		}
+		uid = p.GetUID()
+		owner = clientOwner{kind: linodev1alpha1.ProviderKind, name: n}
+		credentials, version, err = getCredentials(ctx, kube, p)
+	}
+	if err != nil {
+		return linodego.Client{}, err
+	}
+
+	var client linodego.Client
+	if newClientFn != nil {
+		client, err = newClientFn(credentials)
+	} else {
+		client, err = providerClients.Get(uid, owner, version, credentials)
+	}
+	return client, errors.Wrapf(err, "cannot create client for %s %s", ref.Kind, ref.Name)
+}
@@ -0,0 +1,188 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	linodev1alpha1 "github.com/displague/stack-linode/api/v1alpha1"
+)
+
+const (
+	errCredentialsSource  = "unknown provider credentials source"
+	errCredentialsEnv     = "provider credentials environment variable is not set"
+	errCredentialsFsPath  = "provider credentials file path is not set"
+	errCredentialsFsRead  = "cannot read provider credentials file"
+	errCredentialsSecret  = "cannot get provider secret"
+	errCredentialsListing = "cannot list Providers to watch credentials files"
+)
+
+// credentialsFilePollInterval is how often the credentials files of Providers
+// with a Filesystem credentials source are checked for changes.
+const credentialsFilePollInterval = 30 * time.Second
+
+// getCredentials returns the credentials of the supplied Provider from its
+// credentials source, and a version that changes whenever they do: the
+// resourceVersion of a credentials Secret, or a digest of credentials read from
+// the environment or filesystem.
+func getCredentials(ctx context.Context, kube client.Client, p *linodev1alpha1.Provider) ([]byte, string, error) {
+	secret := credentialsSecret{
+		NamespacedName: types.NamespacedName{Namespace: p.GetNamespace(), Name: p.Spec.Secret.Name},
+		key:            p.Spec.Secret.Key,
+	}
+	return readCredentials(ctx, kube, p.CredentialsSource(), secret, p.Spec.Credentials)
+}
+
+// getProviderConfigCredentials returns the credentials of the supplied
+// ProviderConfig from its credentials source, and their version.
+func getProviderConfigCredentials(ctx context.Context, kube client.Client, pc *linodev1alpha1.ProviderConfig) ([]byte, string, error) {
+	secret := credentialsSecret{}
+	if s := pc.Spec.Secret; s != nil {
+		secret = credentialsSecret{NamespacedName: types.NamespacedName{Namespace: s.Namespace, Name: s.Name}, key: s.Key}
+	}
+	return readCredentials(ctx, kube, pc.CredentialsSource(), secret, pc.Spec.Credentials)
+}
+
+// A credentialsSecret is a key of a Secret holding credentials.
+type credentialsSecret struct {
+	types.NamespacedName
+	key string
+}
+
+func readCredentials(ctx context.Context, kube client.Client, source string, secret credentialsSecret, c *linodev1alpha1.ProviderCredentials) ([]byte, string, error) {
+	switch source {
+	case linodev1alpha1.CredentialsSourceSecret:
+		s := &corev1.Secret{}
+		if err := kube.Get(ctx, secret.NamespacedName, s); err != nil {
+			return nil, "", errors.Wrapf(err, "%s %s", errCredentialsSecret, secret.NamespacedName)
+		}
+		return s.Data[secret.key], s.GetResourceVersion(), nil
+	case linodev1alpha1.CredentialsSourceEnvironment:
+		name := linodev1alpha1.DefaultCredentialsEnvironmentVariable
+		if c.Env != nil && c.Env.Name != "" {
+			name = c.Env.Name
+		}
+		credentials, ok := os.LookupEnv(name)
+		if !ok {
+			return nil, "", errors.Errorf("%s: %s", errCredentialsEnv, name)
+		}
+		return []byte(credentials), credentialsDigest([]byte(credentials)), nil
+	case linodev1alpha1.CredentialsSourceFilesystem:
+		if c.Fs == nil || c.Fs.Path == "" {
+			return nil, "", errors.New(errCredentialsFsPath)
+		}
+		// The file is read on every use, so that rotated credentials are
+		// picked up without restarting the controller.
+		credentials, err := ioutil.ReadFile(c.Fs.Path)
+		if err != nil {
+			return nil, "", errors.Wrap(err, errCredentialsFsRead)
+		}
+		return credentials, credentialsDigest(credentials), nil
+	}
+	return nil, "", errors.Errorf("%s: %s", errCredentialsSource, source)
+}
+
+func credentialsDigest(credentials []byte) string {
+	d := sha256.Sum256(credentials)
+	return hex.EncodeToString(d[:])
+}
+
+// A credentialsFileWatcher polls the credentials files of Providers with a
+// Filesystem credentials source, and emits an event for each Provider whose
+// credentials file changed so that its credentials are validated again.
+type credentialsFileWatcher struct {
+	kube     client.Client
+	interval time.Duration
+	events   chan event.GenericEvent
+
+	contents map[types.NamespacedName][]byte
+}
+
+func newCredentialsFileWatcher(kube client.Client) *credentialsFileWatcher {
+	return &credentialsFileWatcher{
+		kube:     kube,
+		interval: credentialsFilePollInterval,
+		events:   make(chan event.GenericEvent),
+		contents: map[types.NamespacedName][]byte{},
+	}
+}
+
+// Start polling credentials files until the supplied channel is closed.
+func (w *credentialsFileWatcher) Start(stop <-chan struct{}) error {
+	t := time.NewTicker(w.interval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return nil
+		case <-t.C:
+			w.poll(stop)
+		}
+	}
+}
+
+func (w *credentialsFileWatcher) poll(stop <-chan struct{}) {
+	l := &linodev1alpha1.ProviderList{}
+	if err := w.kube.List(context.Background(), l); err != nil {
+		providerLog.Error(err, errCredentialsListing)
+		return
+	}
+
+	seen := map[types.NamespacedName]bool{}
+	for i := range l.Items {
+		p := &l.Items[i]
+		if p.CredentialsSource() != linodev1alpha1.CredentialsSourceFilesystem || p.Spec.Credentials.Fs == nil {
+			continue
+		}
+
+		n := types.NamespacedName{Namespace: p.GetNamespace(), Name: p.GetName()}
+		seen[n] = true
+
+		// Unreadable files are reported when the Provider is validated.
+		credentials, _ := ioutil.ReadFile(p.Spec.Credentials.Fs.Path)
+		previous, known := w.contents[n]
+		w.contents[n] = credentials
+		if !known || bytes.Equal(previous, credentials) {
+			continue
+		}
+
+		select {
+		case w.events <- event.GenericEvent{Meta: p, Object: p}:
+		case <-stop:
+			return
+		}
+	}
+
+	for n := range w.contents {
+		if !seen[n] {
+			delete(w.contents, n)
+		}
+	}
+}
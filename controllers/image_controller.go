@@ -0,0 +1,498 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/linode/linodego"
+	"github.com/pkg/errors"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/meta"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+
+	linodev1alpha1 "github.com/displague/stack-linode/api/v1alpha1"
+	"github.com/displague/stack-linode/catalog"
+	"github.com/displague/stack-linode/clients"
+)
+
+const (
+	errNotImage           = "managed resource is not an Image"
+	errImageGet           = "cannot get Image"
+	errImageFind          = "cannot find Image by label"
+	errImageCreate        = "cannot create Image"
+	errImageUpdate        = "cannot update Image"
+	errImageDelete        = "cannot delete Image"
+	errImageUpload        = "cannot upload Image"
+	errImageUploadJob     = "cannot apply Image upload Job"
+	errImageUploadSecret  = "cannot apply Image upload Secret"
+	errImageUploadFailed  = "Image upload Job failed"
+	errImageNoSource      = "none of a Disk reference, Disk ID, Instance reference or upload is set"
+	errImageNoUpload      = "neither a URL nor a PersistentVolumeClaim is set to upload"
+	errImageInstanceDisks = "cannot list Disks of the Instance to capture"
+	errImageNoDisk        = "Instance to capture has no non-swap Disk"
+)
+
+const (
+	// imageUploadImage is the container image used to upload image files.
+	imageUploadImage = "curlimages/curl:7.66.0"
+
+	// imageUploadURLKey is the key of the upload URL in the upload Secret.
+	imageUploadURLKey = "uploadURL"
+
+	// annotationImageID annotates an upload Job with the ID of the Linode
+	// Image it uploads to.
+	annotationImageID = "linode.stack.crossplane.io/image-id"
+
+	imageUploadWorkDir = "/work"
+	imageUploadDataDir = "/data"
+)
+
+// ImageController is responsible for adding the Image
+// controller and its corresponding reconciler to the manager with any runtime configuration.
+type ImageController struct {
+	Options Options
+}
+
+var (
+	imageLog = ctrl.Log.WithName("image.controller")
+)
+
+// SetupWithManager creates a new Image Controller and adds it to the
+// Manager with default RBAC. The Manager will set fields on the Controller and
+// start it when the Manager is Started.
+func (c *ImageController) SetupWithManager(mgr ctrl.Manager) error {
+	r := resource.NewManagedReconciler(mgr,
+		resource.ManagedKind(linodev1alpha1.ImageGroupVersionKind),
+		resource.WithLongWait(c.Options.pollInterval()),
+		resource.WithExternalConnecter(withTracing(linodev1alpha1.ImageKind, withManagementPolicy(linodev1alpha1.ImageKind, &imageConnecter{client: mgr.GetClient(), catalog: c.Options.Catalog}))))
+
+	name := strings.ToLower(fmt.Sprintf("%s.%s", linodev1alpha1.ImageKind, linodev1alpha1.Group))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(c.Options.controllerOptions(linodev1alpha1.ImageKind)).
+		For(&linodev1alpha1.Image{}).
+		Owns(&batchv1.Job{}).
+		Complete(withPause(mgr.GetClient(), &linodev1alpha1.Image{}, r))
+}
+
+type imageConnecter struct {
+	client      client.Client
+	catalog     *catalog.Catalog
+	newClientFn func(credentials []byte) (linodego.Client, error)
+}
+
+// Connect to the supplied resource.Managed (presumed to be an Image) by using
+// the Provider it references to create a new Linode API client.
+func (c *imageConnecter) Connect(ctx context.Context, mg resource.Managed) (resource.ExternalClient, error) {
+	m, ok := mg.(*linodev1alpha1.Image)
+	if !ok {
+		return nil, errors.New(errNotImage)
+	}
+
+	client, err := connectLinode(ctx, c.client, m, c.newClientFn)
+	if err != nil {
+		return nil, err
+	}
+	return &imageExternal{client: client, kube: c.client, catalog: c.catalog}, nil
+}
+
+type imageExternal struct {
+	client  linodego.Client
+	kube    client.Client
+	catalog *catalog.Catalog
+}
+
+// Observe the existing Linode Image, if any.
+func (e *imageExternal) Observe(ctx context.Context, mg resource.Managed) (resource.ExternalObservation, error) {
+	m, ok := mg.(*linodev1alpha1.Image)
+	if !ok {
+		return resource.ExternalObservation{}, errors.New(errNotImage)
+	}
+
+	// Images that are only observed are never created, so the existing
+	// private Linode Image is found by its label.
+	if m.Status.Id == "" && managementPolicy(m) == linodev1alpha1.ManagementPolicyObserveOnly {
+		id, err := e.findImage(ctx, imageLabel(m))
+		if err != nil {
+			return resource.ExternalObservation{}, err
+		}
+		m.Status.Id = id
+	}
+
+	if m.Status.Id == "" {
+		return resource.ExternalObservation{}, nil
+	}
+
+	ctx, log := managedLogger(ctx, imageLog, m)
+	image, err := clients.GetImage(ctx, &e.client, m.Status.Id)
+	if clients.IsNotFound(err) {
+		return resource.ExternalObservation{}, nil
+	}
+	if err != nil {
+		return resource.ExternalObservation{}, errors.Wrap(err, errImageGet)
+	}
+
+	log.V(1).Info("Observe", "imageId", m.Status.Id, "status", image.Status)
+
+	m.Status.Status = image.Status
+	m.Status.Label = image.Label
+	m.Status.Description = image.Description
+	m.Status.Size = image.Size
+
+	switch image.Status {
+	case clients.ImageAvailable:
+		m.Status.SetConditions(runtimev1alpha1.Available())
+		resource.SetBindable(m)
+	case clients.ImagePendingUpload:
+		m.Status.SetConditions(runtimev1alpha1.Creating())
+		if err := e.observeUpload(ctx, m); err != nil {
+			return resource.ExternalObservation{}, err
+		}
+	case clients.ImageCreating:
+		m.Status.SetConditions(runtimev1alpha1.Creating())
+	}
+
+	return resource.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: image.Label == imageLabel(m) && image.Description == m.Spec.Description,
+	}, nil
+}
+
+// observeUpload ensures the Job uploading the image file of a pending Linode
+// Image exists, and returns an error if it failed.
+func (e *imageExternal) observeUpload(ctx context.Context, m *linodev1alpha1.Image) error {
+	if m.Status.UploadJob == "" {
+		return nil
+	}
+	if err := e.ensureUploadJob(ctx, m); err != nil {
+		return err
+	}
+
+	job := &batchv1.Job{}
+	if err := e.kube.Get(ctx, types.NamespacedName{Namespace: m.GetNamespace(), Name: m.Status.UploadJob}, job); err != nil {
+		return errors.Wrap(resource.IgnoreNotFound(err), errImageUploadJob)
+	}
+
+	for _, c := range job.Status.Conditions {
+		if c.Type == batchv1.JobFailed && c.Status == corev1.ConditionTrue {
+			return errors.Errorf("%s: %s", errImageUploadFailed, c.Message)
+		}
+	}
+	return nil
+}
+
+// Create a new Linode Image, either by capturing a Disk or by uploading an
+// image file.
+func (e *imageExternal) Create(ctx context.Context, mg resource.Managed) (resource.ExternalCreation, error) {
+	m, ok := mg.(*linodev1alpha1.Image)
+	if !ok {
+		return resource.ExternalCreation{}, errors.New(errNotImage)
+	}
+
+	m.Status.SetConditions(runtimev1alpha1.Creating())
+
+	if m.Spec.Upload != nil {
+		return resource.ExternalCreation{}, e.upload(ctx, m)
+	}
+
+	diskID, err := e.getCaptureDiskID(ctx, m)
+	if err != nil {
+		return resource.ExternalCreation{}, errors.Wrap(err, errImageCreate)
+	}
+
+	image, err := e.client.CreateImage(ctx, linodego.ImageCreateOptions{
+		DiskID:      diskID,
+		Label:       imageLabel(m),
+		Description: m.Spec.Description,
+	})
+	if err != nil {
+		return resource.ExternalCreation{}, errors.Wrap(err, errImageCreate)
+	}
+
+	m.Status.Id = image.ID
+
+	return resource.ExternalCreation{}, nil
+}
+
+// Update the Linode Image label and description to match the Image.
+func (e *imageExternal) Update(ctx context.Context, mg resource.Managed) (resource.ExternalUpdate, error) {
+	m, ok := mg.(*linodev1alpha1.Image)
+	if !ok {
+		return resource.ExternalUpdate{}, errors.New(errNotImage)
+	}
+
+	description := m.Spec.Description
+	opts := linodego.ImageUpdateOptions{
+		Label:       imageLabel(m),
+		Description: &description,
+	}
+	if _, err := e.client.UpdateImage(ctx, m.Status.Id, opts); err != nil {
+		return resource.ExternalUpdate{}, errors.Wrap(err, errImageUpdate)
+	}
+
+	return resource.ExternalUpdate{}, nil
+}
+
+// Delete the Linode Image. Any upload Job and Secret are owned by the Image
+// and are garbage collected with it.
+func (e *imageExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	m, ok := mg.(*linodev1alpha1.Image)
+	if !ok {
+		return errors.New(errNotImage)
+	}
+
+	m.SetConditions(runtimev1alpha1.Deleting())
+	err := e.client.DeleteImage(ctx, m.Status.Id)
+	if clients.IsNotFound(err) {
+		return nil
+	}
+	return errors.Wrap(err, errImageDelete)
+}
+
+// getCaptureDiskID returns the ID of the Linode Instance Disk the Image is
+// captured from.
+func (e *imageExternal) getCaptureDiskID(ctx context.Context, m *linodev1alpha1.Image) (int, error) {
+	switch {
+	case m.Spec.DiskRef != nil:
+		d, err := getDevice(ctx, e.kube, m.GetNamespace(), &linodev1alpha1.InstanceDevice{DiskRef: m.Spec.DiskRef})
+		if err != nil {
+			return 0, err
+		}
+		return d.DiskID, nil
+	case m.Spec.DiskID != 0:
+		return m.Spec.DiskID, nil
+	case m.Spec.InstanceRef != nil:
+		instanceID, err := getInstanceID(ctx, e.kube, m.GetNamespace(), m.Spec.InstanceRef, 0)
+		if err != nil {
+			return 0, err
+		}
+		disks, err := e.client.ListInstanceDisks(ctx, instanceID, nil)
+		if err != nil {
+			return 0, errors.Wrap(err, errImageInstanceDisks)
+		}
+		for _, d := range disks {
+			if d.Filesystem != linodego.FilesystemSwap {
+				return d.ID, nil
+			}
+		}
+		return 0, errors.New(errImageNoDisk)
+	}
+	return 0, errors.New(errImageNoSource)
+}
+
+// upload creates a Linode Image pending upload, and a Job that uploads the
+// image file to it. The upload URL is passed to the Job in a Secret, as it
+// grants write access to the Image.
+func (e *imageExternal) upload(ctx context.Context, m *linodev1alpha1.Image) error {
+	u := m.Spec.Upload
+	if u.URL == "" && u.PersistentVolumeClaim == nil {
+		return errors.New(errImageNoUpload)
+	}
+	if err := validateRegion(e.catalog, u.Region); err != nil {
+		return errors.Wrap(err, errImageCreate)
+	}
+
+	created, err := clients.CreateImageUpload(ctx, &e.client, clients.ImageUploadCreateOptions{
+		Region:      u.Region,
+		Label:       imageLabel(m),
+		Description: m.Spec.Description,
+	})
+	if err != nil {
+		return errors.Wrap(err, errImageCreate)
+	}
+	m.Status.Id = created.Image.ID
+
+	// The upload URL is only returned when the Linode Image is created, so a
+	// Linode Image whose upload URL cannot be stored is deleted and created
+	// again by the next reconcile.
+	name := m.GetName() + "-upload"
+	if err := e.applyUploadSecret(ctx, m, name, created.UploadTo); err != nil {
+		if derr := e.client.DeleteImage(ctx, m.Status.Id); derr == nil || clients.IsNotFound(derr) {
+			m.Status.Id = ""
+		}
+		return errors.Wrap(err, errImageUpload)
+	}
+	m.Status.UploadJob = name
+
+	// A Job that cannot be created now is created when the pending Linode
+	// Image is next observed.
+	return errors.Wrap(e.ensureUploadJob(ctx, m), errImageUpload)
+}
+
+// applyUploadSecret creates or updates the named Secret, controlled by the
+// supplied Image, to hold the supplied upload URL.
+func (e *imageExternal) applyUploadSecret(ctx context.Context, m *linodev1alpha1.Image, name, url string) error {
+	s := &corev1.Secret{}
+	err := e.kube.Get(ctx, types.NamespacedName{Namespace: m.GetNamespace(), Name: name}, s)
+	if kerrors.IsNotFound(err) {
+		s = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:       m.GetNamespace(),
+				Name:            name,
+				OwnerReferences: []metav1.OwnerReference{imageUploadOwner(m)},
+			},
+			Data: map[string][]byte{imageUploadURLKey: []byte(url)},
+		}
+		return errors.Wrap(e.kube.Create(ctx, s), errImageUploadSecret)
+	}
+	if err != nil {
+		return errors.Wrap(err, errImageUploadSecret)
+	}
+	if !metav1.IsControlledBy(s, m) {
+		return errors.Errorf("%s: Secret %s is not controlled by the Image", errImageUploadSecret, name)
+	}
+	if s.Data == nil {
+		s.Data = map[string][]byte{}
+	}
+	s.Data[imageUploadURLKey] = []byte(url)
+	return errors.Wrap(e.kube.Update(ctx, s), errImageUploadSecret)
+}
+
+// ensureUploadJob creates the Job, controlled by the supplied Image, that
+// uploads the image file of its pending Linode Image, unless it exists. A Job
+// left over from uploading a previous Linode Image is deleted, and replaced
+// once it is gone.
+func (e *imageExternal) ensureUploadJob(ctx context.Context, m *linodev1alpha1.Image) error {
+	job := &batchv1.Job{}
+	err := e.kube.Get(ctx, types.NamespacedName{Namespace: m.GetNamespace(), Name: m.Status.UploadJob}, job)
+	if kerrors.IsNotFound(err) {
+		job = imageUploadJob(m, m.Status.UploadJob)
+		job.SetOwnerReferences([]metav1.OwnerReference{imageUploadOwner(m)})
+		return errors.Wrap(e.kube.Create(ctx, job), errImageUploadJob)
+	}
+	if err != nil {
+		return errors.Wrap(err, errImageUploadJob)
+	}
+	if !metav1.IsControlledBy(job, m) {
+		return errors.Errorf("%s: Job %s is not controlled by the Image", errImageUploadJob, job.GetName())
+	}
+	if job.GetAnnotations()[annotationImageID] == m.Status.Id {
+		return nil
+	}
+	if job.GetDeletionTimestamp() == nil {
+		if err := e.kube.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil && !kerrors.IsNotFound(err) {
+			return errors.Wrap(err, errImageUploadJob)
+		}
+	}
+	return errors.Errorf("%s: waiting for the Job of a previous upload to be deleted", errImageUploadJob)
+}
+
+// imageUploadOwner returns the owner reference of the Secret and Job used to
+// upload the image file of the supplied Image, which are garbage collected
+// with it.
+func imageUploadOwner(m *linodev1alpha1.Image) metav1.OwnerReference {
+	return meta.AsController(meta.ReferenceTo(m, linodev1alpha1.ImageGroupVersionKind))
+}
+
+// imageUploadJob returns a Job that uploads the image file of the supplied
+// Image to the upload URL stored in the named Secret.
+func imageUploadJob(m *linodev1alpha1.Image, name string) *batchv1.Job {
+	u := m.Spec.Upload
+	backoffLimit := int32(3)
+
+	file := path.Join(imageUploadWorkDir, "image.img.gz")
+	script := ""
+	volumes := []corev1.Volume{{
+		Name:         "work",
+		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+	}}
+	mounts := []corev1.VolumeMount{{Name: "work", MountPath: imageUploadWorkDir}}
+
+	if u.PersistentVolumeClaim != nil {
+		file = path.Join(imageUploadDataDir, u.PersistentVolumeClaim.Path)
+		volumes = append(volumes, corev1.Volume{
+			Name: "data",
+			VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: u.PersistentVolumeClaim.ClaimName,
+				ReadOnly:  true,
+			}},
+		})
+		mounts = append(mounts, corev1.VolumeMount{Name: "data", MountPath: imageUploadDataDir, ReadOnly: true})
+	} else {
+		script = `curl -fsSL -o "$IMAGE_FILE" "$SOURCE_URL" && `
+	}
+	script += `curl -fsS -X PUT -H "Content-Type: application/octet-stream" -T "$IMAGE_FILE" "$UPLOAD_URL"`
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   m.GetNamespace(),
+			Name:        name,
+			Annotations: map[string]string{annotationImageID: m.Status.Id},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &backoffLimit,
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					Volumes:       volumes,
+					Containers: []corev1.Container{{
+						Name:    "upload",
+						Image:   imageUploadImage,
+						Command: []string{"/bin/sh", "-c", script},
+						Env: []corev1.EnvVar{
+							{Name: "IMAGE_FILE", Value: file},
+							{Name: "SOURCE_URL", Value: u.URL},
+							{
+								Name: "UPLOAD_URL",
+								ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+									LocalObjectReference: corev1.LocalObjectReference{Name: name},
+									Key:                  imageUploadURLKey,
+								}},
+							},
+						},
+						VolumeMounts: mounts,
+					}},
+				},
+			},
+		},
+	}
+}
+
+// findImage returns the ID of the private Linode Image with the supplied
+// label, or an empty string if there is none.
+func (e *imageExternal) findImage(ctx context.Context, label string) (string, error) {
+	images, err := e.client.ListImages(ctx, linodego.NewListOptions(0, fmt.Sprintf(`{"label": %q, "is_public": false}`, label)))
+	if err != nil {
+		return "", errors.Wrap(err, errImageFind)
+	}
+	if len(images) == 0 {
+		return "", nil
+	}
+	return images[0].ID, nil
+}
+
+// imageLabel returns the label of the Linode Image, defaulting to the name of
+// the Image.
+func imageLabel(m *linodev1alpha1.Image) string {
+	if m.Spec.Label != "" {
+		return m.Spec.Label
+	}
+	return m.GetName()
+}
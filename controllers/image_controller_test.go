@@ -0,0 +1,127 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	linodev1alpha1 "github.com/displague/stack-linode/api/v1alpha1"
+)
+
+func TestImageUpload(t *testing.T) {
+	ctx := context.Background()
+	newImage := func() *linodev1alpha1.Image {
+		m := &linodev1alpha1.Image{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "debian", UID: "image-uid"}}
+		m.Spec.Upload = &linodev1alpha1.ImageUploadSource{URL: "https://example.org/debian.img.gz", Region: "us-east"}
+		m.Status.Id = "private/2"
+		m.Status.UploadJob = "debian-upload"
+		return m
+	}
+	key := types.NamespacedName{Namespace: "default", Name: "debian-upload"}
+	owned := func(imageID string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{
+			Namespace:       key.Namespace,
+			Name:            key.Name,
+			Annotations:     map[string]string{annotationImageID: imageID},
+			OwnerReferences: []metav1.OwnerReference{imageUploadOwner(newImage())},
+		}
+	}
+
+	cases := map[string]struct {
+		existing   []runtime.Object
+		wantErr    bool
+		wantURL    string
+		wantImage  string
+		wantNoJobs bool
+	}{
+		"Created": {
+			wantURL:   "https://upload/2",
+			wantImage: "private/2",
+		},
+		"AlreadyCreated": {
+			existing: []runtime.Object{
+				&corev1.Secret{ObjectMeta: owned("private/1"), Data: map[string][]byte{imageUploadURLKey: []byte("https://upload/1")}},
+				&batchv1.Job{ObjectMeta: owned("private/2")},
+			},
+			wantURL:   "https://upload/2",
+			wantImage: "private/2",
+		},
+		"PreviousUploadJob": {
+			existing: []runtime.Object{
+				&batchv1.Job{ObjectMeta: owned("private/1")},
+			},
+			wantErr:    true,
+			wantURL:    "https://upload/2",
+			wantNoJobs: true,
+		},
+		"SecretNotControlled": {
+			existing: []runtime.Object{
+				&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name}, Data: map[string][]byte{imageUploadURLKey: []byte("https://elsewhere")}},
+			},
+			wantErr: true,
+			wantURL: "https://elsewhere",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			kube := fake.NewFakeClient(tc.existing...)
+			e := &imageExternal{kube: kube}
+			m := newImage()
+
+			err := e.applyUploadSecret(ctx, m, key.Name, "https://upload/2")
+			if err == nil {
+				err = e.ensureUploadJob(ctx, m)
+			}
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("want error %t, got %v", tc.wantErr, err)
+			}
+
+			s := &corev1.Secret{}
+			if err := kube.Get(ctx, key, s); err != nil {
+				t.Fatalf("cannot get upload Secret: %v", err)
+			}
+			if got := string(s.Data[imageUploadURLKey]); got != tc.wantURL {
+				t.Errorf("upload Secret: want URL %s, got %s", tc.wantURL, got)
+			}
+
+			jobs := &batchv1.JobList{}
+			if err := kube.List(ctx, jobs); err != nil {
+				t.Fatalf("cannot list Jobs: %v", err)
+			}
+			switch {
+			case tc.wantNoJobs && len(jobs.Items) != 0:
+				t.Errorf("want the previous upload Job deleted, got %d Jobs", len(jobs.Items))
+			case tc.wantImage != "" && (len(jobs.Items) != 1 || jobs.Items[0].GetAnnotations()[annotationImageID] != tc.wantImage):
+				t.Errorf("want one upload Job for %s, got %+v", tc.wantImage, jobs.Items)
+			}
+			for _, j := range jobs.Items {
+				if !metav1.IsControlledBy(&j, m) {
+					t.Errorf("upload Job %s is not controlled by the Image", j.GetName())
+				}
+			}
+		})
+	}
+}
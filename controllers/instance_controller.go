@@ -18,35 +18,74 @@ import (
 	"crypto/rand"
 	"encoding/base64"
 	"fmt"
+	"net"
 	"net/http"
 	"strings"
+	"time"
 
 	linodego "github.com/linode/linodego"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
-	"github.com/crossplaneio/crossplane-runtime/pkg/meta"
 	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
 
 	linodev1alpha1 "github.com/displague/stack-linode/api/v1alpha1"
+	"github.com/displague/stack-linode/catalog"
 	"github.com/displague/stack-linode/clients"
 )
 
 const (
-	errNewClient      = "cannot create new Instance client"
-	errNotInstance    = "managed resource is not an Instance"
-	errInstanceCreate = "cannot create Instance"
-	errInstanceDelete = "cannot delete Instance"
+	errNewClient       = "cannot create new Instance client"
+	errNotInstance     = "managed resource is not an Instance"
+	errInstanceCreate  = "cannot create Instance"
+	errInstanceDelete  = "cannot delete Instance"
+	errInstanceGet     = "cannot get Instance"
+	errInstanceReboot  = "cannot reboot Instance"
+	errInstanceRescue  = "cannot boot Instance into Rescue Mode"
+	errInstanceClone   = "cannot clone Instance"
+	errInstanceRestore = "cannot restore Instance from Backup"
+	errInstanceMigrate = "cannot migrate Instance"
+	errInstanceResize  = "cannot resize Instance"
+	errInstanceRename  = "cannot rename Instance"
+	errInstanceBoot    = "cannot boot Instance"
+	errInstanceStop    = "cannot shut down Instance"
+	errInstanceConfigs = "cannot list Instance configs"
+	errInstanceEvents  = "cannot list Instance events"
+	errInstanceProtect = "Instance is protected from deletion"
+	errInstanceFind    = "cannot find Instance by label"
+	errCloneSource     = "cannot get Instance to clone"
+	errCloneNotCreated = "Instance to clone has not been created"
+)
+
+// Reasons for the events emitted by the Instance controller.
+const (
+	reasonInstanceCreated      = "CreatedInstance"
+	reasonInstanceBooting      = "BootingInstance"
+	reasonInstanceShuttingDown = "ShuttingDownInstance"
+	reasonInstanceRebooting    = "RebootingInstance"
+	reasonInstanceRescuing     = "RescuingInstance"
+	reasonInstanceResizing     = "ResizingInstance"
+	reasonInstanceMigrating    = "MigratingInstance"
+	reasonInstanceRenamed      = "RenamedInstance"
+	reasonInstanceDeleted      = "DeletedInstance"
+
+	reasonCannotObserveInstance = "CannotObserveInstance"
+	reasonCannotCreateInstance  = "CannotCreateInstance"
+	reasonCannotUpdateInstance  = "CannotUpdateInstance"
+	reasonCannotDeleteInstance  = "CannotDeleteInstance"
 )
 
 // InstanceController is responsible for adding the Instance
 // controller and its corresponding reconciler to the manager with any runtime configuration.
-type InstanceController struct{}
+type InstanceController struct {
+	Options Options
+}
 
 var (
 	controllerLog = ctrl.Log.WithName("instance.controller")
@@ -56,22 +95,27 @@ var (
 // Manager with default RBAC. The Manager will set fields on the Controller and
 // start it when the Manager is Started.
 func (c *InstanceController) SetupWithManager(mgr ctrl.Manager) error {
+	name := strings.ToLower(fmt.Sprintf("%s.%s", linodev1alpha1.InstanceKind, linodev1alpha1.Group))
+
 	r := resource.NewManagedReconciler(mgr,
 		resource.ManagedKind(linodev1alpha1.InstanceGroupVersionKind),
+		resource.WithLongWait(c.Options.pollInterval()),
 		resource.WithManagedConnectionPublishers(),
-		resource.WithExternalConnecter(&connecter{client: mgr.GetClient()}))
-
-	name := strings.ToLower(fmt.Sprintf("%s.%s", linodev1alpha1.InstanceKind, linodev1alpha1.Group))
+		resource.WithExternalConnecter(withTracing(linodev1alpha1.InstanceKind, withManagementPolicy(linodev1alpha1.InstanceKind, &connecter{client: mgr.GetClient(), recorder: mgr.GetEventRecorderFor(name), catalog: c.Options.Catalog}))))
+	rq := &instanceRequeuer{Reconciler: r, kube: mgr.GetClient(), interval: c.Options.transitionalPollInterval()}
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
+		WithOptions(c.Options.controllerOptions(linodev1alpha1.InstanceKind)).
 		For(&linodev1alpha1.Instance{}).
-		Complete(r)
+		Complete(withPause(mgr.GetClient(), &linodev1alpha1.Instance{}, rq))
 }
 
 type connecter struct {
 	client      client.Client
-	newClientFn func(credentials []byte) linodego.Client
+	recorder    record.EventRecorder
+	catalog     *catalog.Catalog
+	newClientFn func(credentials []byte) (linodego.Client, error)
 }
 
 // Connect to the supplied resource.Managed (presumed to be an
@@ -81,32 +125,22 @@ func (c *connecter) Connect(ctx context.Context, mg resource.Managed) (resource.
 	m, ok := mg.(*linodev1alpha1.Instance)
 	if !ok {
 		err := errors.New(errNotInstance)
-		controllerLog.Error(err, "Connect", "mg", mg)
 		return nil, err
 	}
 
-	controllerLog.Info("Connect", "spec", m.Spec, "status", m.Status)
-
-	p := &linodev1alpha1.Provider{}
-	n := meta.NamespacedNameOf(m.Spec.ProviderReference)
-	if err := c.client.Get(ctx, n, p); err != nil {
-		return nil, errors.Wrapf(err, "cannot get provider %s", n)
-	}
-
-	s := &corev1.Secret{}
-	n = types.NamespacedName{Namespace: p.GetNamespace(), Name: p.Spec.Secret.Name}
-	if err := c.client.Get(ctx, n, s); err != nil {
-		return nil, errors.Wrapf(err, "cannot get provider secret %s", n)
-	}
-	newClientFn := clients.NewClient
-	if c.newClientFn != nil {
-		newClientFn = c.newClientFn
+	client, err := connectLinode(ctx, c.client, m, c.newClientFn)
+	if err != nil {
+		return nil, err
 	}
-	client := newClientFn(s.Data[p.Spec.Secret.Key])
-	return &external{client: client}, errors.Wrap(nil, errNewClient)
+	return &external{client: client, kube: c.client, recorder: c.recorder, catalog: c.catalog}, errors.Wrap(nil, errNewClient)
 }
 
-type external struct{ client linodego.Client }
+type external struct {
+	client   linodego.Client
+	kube     client.Client
+	recorder record.EventRecorder
+	catalog  *catalog.Catalog
+}
 
 // Observe the existing external resource, if any. The resource.ManagedReconciler
 // calls Observe in order to determine whether an external resource needs to be
@@ -117,37 +151,85 @@ func (e *external) Observe(ctx context.Context, mg resource.Managed) (resource.E
 		return resource.ExternalObservation{}, errors.New(errNotInstance)
 	}
 
-	controllerLog.Info("Observe", "spec", m.Spec, "status", m.Status)
+	// Instances that are only observed are never created, so the existing
+	// Linode Instance is found by its label, which is unique to the account.
+	if m.Status.Id == 0 && managementPolicy(m) == linodev1alpha1.ManagementPolicyObserveOnly {
+		id, err := e.findInstance(ctx, m.Spec.Label)
+		if err != nil {
+			e.recorder.Event(m, corev1.EventTypeWarning, reasonCannotObserveInstance, err.Error())
+			return resource.ExternalObservation{}, err
+		}
+		m.Status.Id = id
+	}
 
 	if m.Status.Id == 0 {
 		return resource.ExternalObservation{}, nil
 	}
 
+	ctx, log := managedLogger(ctx, controllerLog, m, "instanceId", m.Status.Id)
 	instance, err := e.client.GetInstance(ctx, m.Status.Id)
-
-	controllerLog.Info("Observe", "instanceId", m.Status.Id, "err", err)
-
 	if err != nil {
-		if e, ok := err.(*linodego.Error); ok && e.Code == 404 {
+		if clients.IsNotFound(err) {
 			return resource.ExternalObservation{}, nil
 		}
+		e.recorder.Event(m, corev1.EventTypeWarning, reasonCannotObserveInstance, err.Error())
+		return resource.ExternalObservation{}, errors.Wrap(err, errInstanceGet)
 	}
 
-	controllerLog.Info("Observe", "wantLabel", m.Spec.Label, "gotLabel", instance.Label)
-	switch m.Status.Status {
-	case string(linodego.InstanceRunning):
-		m.Status.SetConditions(runtimev1alpha1.Available())
+	log.V(1).Info("Observe", "status", instance.Status, "wantLabel", m.Spec.Label, "gotLabel", instance.Label)
+
+	// Linode reports Instances in Rescue Mode as running. They remain in
+	// Rescue Mode until they are rebooted or shut down.
+	status := string(instance.Status)
+	if m.Status.Status == linodev1alpha1.InstanceStatusRescue && isRescueStatus[instance.Status] {
+		status = linodev1alpha1.InstanceStatusRescue
+	}
+
+	desired := m.Spec.Status
+	if desired == "" {
+		desired = string(linodego.InstanceRunning)
+	}
+
+	// Instances without a Config to boot are left offline, rather than
+	// failing to boot them on every reconcile.
+	if desired == string(linodego.InstanceRunning) && isOffStatus[status] {
+		bootable, err := e.bootable(ctx, m)
+		if err != nil {
+			e.recorder.Event(m, corev1.EventTypeWarning, reasonCannotObserveInstance, err.Error())
+			return resource.ExternalObservation{}, err
+		}
+		if !bootable {
+			m.Status.SetConditions(linodev1alpha1.Unbootable())
+			desired = string(linodego.InstanceOffline)
+		} else {
+			m.Status.SetConditions(linodev1alpha1.Bootable())
+		}
+	}
+	ready := linodev1alpha1.InstanceReady(status, desired)
+
+	// Instances remain Creating while Linode provisions and first boots them.
+	// The first time an Instance that is being created is ready marks the
+	// time it took to become ready.
+	creating := linodev1alpha1.GetCondition(m.Status.ConditionedStatus, runtimev1alpha1.TypeReady).Reason == runtimev1alpha1.ReasonCreating
+	if !creating || !isTransitionalStatus[status] {
+		if creating && ready.Status == corev1.ConditionTrue {
+			instanceTimeToReady.Observe(time.Since(m.GetCreationTimestamp().Time).Seconds())
+		}
+		m.Status.SetConditions(ready)
+	}
+	m.Status.SetConditions(linodev1alpha1.InstancePowerState(status))
+	m.Status.SetConditions(deletionProtection(m))
+	if ready.Status == corev1.ConditionTrue {
 		resource.SetBindable(m)
-	case string(linodego.InstanceProvisioning):
-		m.Status.SetConditions(runtimev1alpha1.Creating())
 	}
 
 	// Store observed values in Status
 	m.Status.Id = instance.ID
 	m.Status.Label = instance.Label
-	m.Status.Status = string(instance.Status)
+	m.Status.Status = status
 	m.Status.Region = instance.Region
 	m.Status.Type = instance.Type
+	m.Status.EstimatedMonthlyCost = instanceMonthlyCost(e.catalog, instance.Type)
 	m.Status.Image = instance.Image
 	m.Status.IPv4 = []string{}
 	for _, ip := range instance.IPv4 {
@@ -158,19 +240,47 @@ func (e *external) Observe(ctx context.Context, mg resource.Managed) (resource.E
 	// Compare observed (GetInstance()) to desired (spec)
 	upToDate := m.Spec.Label == "" || instance.Label == m.Spec.Label
 	isOnOrOff := map[string]bool{
-		string(linodego.InstanceRunning): true,
-		string(linodego.InstanceOffline): true,
+		string(linodego.InstanceRunning):     true,
+		string(linodego.InstanceOffline):     true,
+		linodev1alpha1.InstanceStatusStopped: true,
+		linodev1alpha1.InstanceStatusRescue:  true,
 	}
 
-	needsPowerToggle := (!isOnOrOff[string(instance.Status)] || m.Spec.Status != string(instance.Status))
-	upToDate = upToDate && !needsPowerToggle
+	needsPowerToggle := !isOnOrOff[status] || ready.Status != corev1.ConditionTrue
+	upToDate = upToDate && !needsPowerToggle && !rebootRequested(m)
+
+	needsMigration, err := e.observeMigration(ctx, m, instance)
+	if err != nil {
+		e.recorder.Event(m, corev1.EventTypeWarning, reasonCannotObserveInstance, err.Error())
+		return resource.ExternalObservation{}, err
+	}
+	upToDate = upToDate && !needsMigration && !typeChangeRequested(m, instance)
 
 	return resource.ExternalObservation{
-		ResourceExists:   true,
-		ResourceUpToDate: upToDate,
+		ResourceExists:    true,
+		ResourceUpToDate:  upToDate,
+		ConnectionDetails: instanceConnectionDetails(instance),
 	}, nil
 }
 
+// privateIPv4 is the range of private IPv4 addresses assigned by Linode.
+var privateIPv4 = &net.IPNet{IP: net.IPv4(192, 168, 128, 0), Mask: net.CIDRMask(17, 32)}
+
+// instanceConnectionDetails returns the addresses of the supplied Linode
+// Instance. They are published each time the Instance is observed, so that
+// Instances that are only observed publish them too. The root password is
+// only known to, and published by, the controller that created the Instance.
+func instanceConnectionDetails(instance *linodego.Instance) resource.ConnectionDetails {
+	details := resource.ConnectionDetails{"ipv6": []byte(instance.IPv6)}
+	for _, ip := range instance.IPv4 {
+		if ip != nil && !privateIPv4.Contains(*ip) {
+			details["ipv4"] = []byte(ip.String())
+			break
+		}
+	}
+	return details
+}
+
 // Create a new external resource based on the specification of our managed
 // resource. resource.ManagedReconciler only calls Create if Observe reported
 // that the external resource did not exist.
@@ -179,33 +289,76 @@ func (e *external) Create(ctx context.Context, mg resource.Managed) (resource.Ex
 	if !ok {
 		return resource.ExternalCreation{}, errors.New(errNotInstance)
 	}
-	controllerLog.Info("Create", "spec", m.Spec, "status", m.Status)
+	ctx, log := managedLogger(ctx, controllerLog, m)
+	log.V(1).Info("Create", "region", m.Spec.Region, "type", m.Spec.Type)
 
 	m.Status.SetConditions(runtimev1alpha1.Creating())
 
-	booted := m.Spec.Status == string(linodego.InstanceRunning)
-	rootPass, _ := createRandomRootPassword()
-	instance, err := e.client.CreateInstance(ctx, linodego.InstanceCreateOptions{
+	if err := validateInstance(e.catalog, m); err != nil {
+		return resource.ExternalCreation{}, e.createFailed(m, errors.Wrap(err, errInstanceCreate))
+	}
+
+	if m.Spec.CloneFrom != nil {
+		creation, err := e.clone(ctx, m)
+		if err != nil {
+			e.recorder.Event(m, corev1.EventTypeWarning, reasonCannotCreateInstance, err.Error())
+			return creation, err
+		}
+		e.recorder.Eventf(m, corev1.EventTypeNormal, reasonInstanceCreated, "Cloned Linode Instance %d", m.Status.Id)
+		return creation, nil
+	}
+
+	opts := linodego.InstanceCreateOptions{
 		Label:           m.Spec.Label,
 		Region:          m.Spec.Region,
 		Type:            m.Spec.Type,
 		AuthorizedUsers: m.Spec.AuthorizedUsers,
-		Image:           m.Spec.Image,
-		Booted:          &booted,
-		RootPass:        rootPass,
-	})
+	}
+	details := resource.ConnectionDetails{}
+
+	image, err := getImageID(ctx, e.kube, m.GetNamespace(), m.Spec.ImageRef, m.Spec.Image)
+	if err != nil {
+		return resource.ExternalCreation{}, e.createFailed(m, errors.Wrap(err, errInstanceCreate))
+	}
+
+	// Instances without an Image are created without Disks or Configs, and
+	// cannot be booted until InstanceDisks and InstanceConfigs are added.
+	// Instances restored from a Backup take their Disks and Configs from it.
+	switch {
+	case m.Spec.RestoreFrom != nil:
+		src := m.Spec.RestoreFrom
+		backupID, err := getBackupID(ctx, e.kube, m.GetNamespace(), src.SnapshotRef, src.BackupID)
+		if err != nil {
+			return resource.ExternalCreation{}, e.createFailed(m, errors.Wrap(err, errInstanceRestore))
+		}
+		booted := m.Spec.Status == string(linodego.InstanceRunning)
+		opts.BackupID = backupID
+		opts.Booted = &booted
+	case image != "":
+		booted := m.Spec.Status == string(linodego.InstanceRunning)
+		rootPass, _ := createRandomRootPassword()
+		keys, err := getAuthorizedKeys(ctx, e.kube, m.GetNamespace(), m.Spec.SSHKeyRefs)
+		if err != nil {
+			return resource.ExternalCreation{}, e.createFailed(m, errors.Wrap(err, errInstanceCreate))
+		}
+		opts.Image = image
+		opts.AuthorizedKeys = keys
+		opts.Booted = &booted
+		opts.RootPass = rootPass
+		details["rootPass"] = []byte(rootPass)
+	}
+
+	instance, err := e.client.CreateInstance(ctx, opts)
 	if err != nil {
-		return resource.ExternalCreation{}, errors.Wrap(err, errInstanceCreate)
+		return resource.ExternalCreation{}, e.createFailed(m, errors.Wrap(err, errInstanceCreate))
 	}
-	m.Status.SetConditions(runtimev1alpha1.Available())
 
 	m.Status.Id = instance.ID
+	e.recorder.Eventf(m, corev1.EventTypeNormal, reasonInstanceCreated, "Created Linode Instance %d in %s", instance.ID, instance.Region)
+	details["ipv6"] = []byte(instance.IPv6)
 
 	return resource.ExternalCreation{
-		ConnectionDetails: resource.ConnectionDetails{
-			"rootPass": []byte(rootPass),
-			"ipv6":     []byte(instance.IPv6),
-		},
+		ConnectionDetails: details,
 	}, nil
 }
 
@@ -219,18 +372,98 @@ func (e *external) Update(ctx context.Context, mg resource.Managed) (resource.Ex
 		return resource.ExternalUpdate{}, errors.New(errNotInstance)
 	}
 
+	ctx, log := managedLogger(ctx, controllerLog, m, "instanceId", m.Status.Id)
 	instance, errGetting := e.client.GetInstance(ctx, m.Status.Id)
 	if errGetting != nil {
-		return resource.ExternalUpdate{}, err
+		return resource.ExternalUpdate{}, e.updateFailed(m, errors.Wrap(errGetting, errInstanceGet))
+	}
+
+	if regionChangeRequested(m, instance) && !linodev1alpha1.IsConditionTrue(m.Status.ConditionedStatus, linodev1alpha1.TypeMigration) {
+		if err := validateRegion(e.catalog, m.Spec.Region); err != nil {
+			return resource.ExternalUpdate{}, e.updateFailed(m, errors.Wrap(err, errInstanceMigrate))
+		}
+		if err := clients.MigrateInstance(ctx, &e.client, m.Status.Id, m.Spec.Region); err != nil {
+			return resource.ExternalUpdate{}, e.updateFailed(m, errors.Wrap(err, errInstanceMigrate))
+		}
+		m.Status.SetConditions(linodev1alpha1.Migrating(m.Spec.Region, 0))
+		e.recorder.Eventf(m, corev1.EventTypeNormal, reasonInstanceMigrating, "Migrating Linode Instance from %s to %s", instance.Region, m.Spec.Region)
+		return resource.ExternalUpdate{}, nil
+	}
+
+	// Resizing shuts the Instance down, and boots it again if it was running.
+	if typeChangeRequested(m, instance) && !regionChangeRequested(m, instance) {
+		if err := validateType(e.catalog, m.Spec.Type); err != nil {
+			return resource.ExternalUpdate{}, e.updateFailed(m, errors.Wrap(err, errInstanceResize))
+		}
+		if err := e.client.ResizeInstance(ctx, m.Status.Id, linodego.InstanceResizeOptions{Type: m.Spec.Type}); err != nil {
+			return resource.ExternalUpdate{}, e.updateFailed(m, errors.Wrap(err, errInstanceResize))
+		}
+		e.recorder.Eventf(m, corev1.EventTypeNormal, reasonInstanceResizing, "Resizing Linode Instance from %s to %s", instance.Type, m.Spec.Type)
+		return resource.ExternalUpdate{}, nil
+	}
+
+	// Renaming does not interrupt the Instance, so it is renamed alongside
+	// any power change.
+	if m.Spec.Label != "" && instance.Label != m.Spec.Label {
+		if _, err := e.client.UpdateInstance(ctx, m.Status.Id, linodego.InstanceUpdateOptions{Label: m.Spec.Label}); err != nil {
+			return resource.ExternalUpdate{}, e.updateFailed(m, errors.Wrap(err, errInstanceRename))
+		}
+		m.Status.Label = m.Spec.Label
+		e.recorder.Eventf(m, corev1.EventTypeNormal, reasonInstanceRenamed, "Renamed Linode Instance from %s to %s", instance.Label, m.Spec.Label)
+	}
+
+	// A pending reboot request is satisfied by any power change, and is moot
+	// while the Instance is meant to be offline. It is left pending while
+	// the Instance is busy with another transition.
+	rebooted := m.Spec.Status == string(linodego.InstanceOffline)
+
+	var reason, msg string
+	switch {
+	case m.Spec.Status == string(linodego.InstanceOffline) &&
+		instance.Status == linodego.InstanceRunning:
+		err = errors.Wrap(e.client.ShutdownInstance(ctx, m.Status.Id), errInstanceStop)
+		reason, msg = reasonInstanceShuttingDown, "Shutting down Linode Instance"
+	case m.Spec.Status == linodev1alpha1.InstanceStatusRescue &&
+		m.Status.Status != linodev1alpha1.InstanceStatusRescue:
+		err = e.rescue(ctx, m)
+		reason, msg = reasonInstanceRescuing, "Booting Linode Instance into Rescue Mode"
+		rebooted = true
+	case m.Spec.Status != linodev1alpha1.InstanceStatusRescue &&
+		m.Status.Status == linodev1alpha1.InstanceStatusRescue:
+		// Leave Rescue Mode by rebooting into the regular boot config.
+		err = errors.Wrap(e.client.RebootInstance(ctx, m.Status.Id, m.Spec.BootConfigID), errInstanceReboot)
+		if err == nil {
+			m.Status.Status = string(linodego.InstanceRebooting)
+		}
+		reason, msg = reasonInstanceRebooting, "Rebooting Linode Instance out of Rescue Mode"
+		rebooted = true
+	case m.Spec.Status != string(linodego.InstanceOffline) &&
+		isOffStatus[string(instance.Status)] &&
+		linodev1alpha1.GetCondition(m.Status.ConditionedStatus, linodev1alpha1.TypeBootable).Status != corev1.ConditionFalse:
+		err = errors.Wrap(e.client.BootInstance(ctx, m.Status.Id, m.Spec.BootConfigID), errInstanceBoot)
+		reason, msg = reasonInstanceBooting, "Booting Linode Instance"
+		rebooted = true
+	case rebootRequested(m) && m.Status.Status == linodev1alpha1.InstanceStatusRescue:
+		err = e.rescue(ctx, m)
+		reason, msg = reasonInstanceRescuing, "Rebooting Linode Instance into Rescue Mode as requested"
+		rebooted = true
+	case rebootRequested(m) && instance.Status == linodego.InstanceRunning:
+		err = errors.Wrap(e.client.RebootInstance(ctx, m.Status.Id, m.Spec.BootConfigID), errInstanceReboot)
+		reason, msg = reasonInstanceRebooting, "Rebooting Linode Instance as requested"
+		rebooted = true
 	}
 
-	if m.Spec.Status == string(linodego.InstanceOffline) &&
-		instance.Status == linodego.InstanceRunning {
-		err = e.client.ShutdownInstance(ctx, m.Status.Id)
-	} else if instance.Status == linodego.InstanceOffline {
-		err = e.client.BootInstance(ctx, m.Status.Id, 0)
+	switch {
+	case err != nil:
+		err = e.updateFailed(m, err)
+	case reason != "":
+		e.recorder.Event(m, corev1.EventTypeNormal, reason, msg)
 	}
-	controllerLog.Info("Update", "spec", m.Spec, "status", m.Status)
+
+	if err == nil && rebooted && rebootRequested(m) {
+		m.Status.LastRebootRequestedAt = m.GetAnnotations()[linodev1alpha1.AnnotationRebootRequestedAt]
+	}
+	log.V(1).Info("Update", "wantStatus", m.Spec.Status, "gotStatus", instance.Status, "action", reason)
 
 	return resource.ExternalUpdate{}, err
 }
@@ -242,7 +475,14 @@ func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
 	if !ok {
 		return errors.New(errNotInstance)
 	}
-	controllerLog.Info("Delete", "spec", m.Spec, "status", m.Status)
+	ctx, log := managedLogger(ctx, controllerLog, m, "instanceId", m.Status.Id)
+	log.V(1).Info("Delete")
+
+	if m.DeletionProtected() {
+		m.Status.SetConditions(linodev1alpha1.DeletionRefused())
+		e.recorder.Event(m, corev1.EventTypeWarning, reasonCannotDeleteInstance, errInstanceProtect)
+		return errors.Wrap(errors.New(errInstanceProtect), errInstanceDelete)
+	}
 
 	m.SetConditions(runtimev1alpha1.Deleting())
 	err := e.client.DeleteInstance(ctx, m.Status.Id)
@@ -251,9 +491,195 @@ func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
 		if e, ok := err.(*linodego.Error); ok && e.Code == http.StatusNotFound {
 			return nil
 		}
+		e.recorder.Event(m, corev1.EventTypeWarning, reasonCannotDeleteInstance, err.Error())
+		return errors.Wrap(err, errInstanceDelete)
+	}
+
+	e.recorder.Eventf(m, corev1.EventTypeNormal, reasonInstanceDeleted, "Deleted Linode Instance %d", m.Status.Id)
+	return nil
+}
+
+// findInstance returns the ID of the Linode Instance with the supplied label,
+// or 0 if there is none.
+func (e *external) findInstance(ctx context.Context, label string) (int, error) {
+	if label == "" {
+		return 0, nil
+	}
+	instances, err := e.client.ListInstances(ctx, linodego.NewListOptions(0, fmt.Sprintf(`{"label": %q}`, label)))
+	if err != nil {
+		return 0, errors.Wrap(err, errInstanceFind)
+	}
+	if len(instances) == 0 {
+		return 0, nil
+	}
+	return instances[0].ID, nil
+}
+
+// deletionProtection returns the deletion protection condition of the supplied
+// Instance. A refused deletion is reported until protection is removed.
+func deletionProtection(m *linodev1alpha1.Instance) runtimev1alpha1.Condition {
+	if !m.DeletionProtected() {
+		return linodev1alpha1.DeletionUnprotected()
 	}
+	if linodev1alpha1.GetCondition(m.Status.ConditionedStatus, linodev1alpha1.TypeDeletionProtection).Reason == linodev1alpha1.ReasonDeletionRefused {
+		return linodev1alpha1.DeletionRefused()
+	}
+	return linodev1alpha1.DeletionProtected()
+}
+
+// createFailed emits a warning event for the supplied error creating the
+// Instance, and returns it.
+func (e *external) createFailed(m *linodev1alpha1.Instance, err error) error {
+	e.recorder.Event(m, corev1.EventTypeWarning, reasonCannotCreateInstance, err.Error())
+	return err
+}
+
+// updateFailed emits a warning event for the supplied error updating the
+// Instance, and returns it.
+func (e *external) updateFailed(m *linodev1alpha1.Instance, err error) error {
+	e.recorder.Event(m, corev1.EventTypeWarning, reasonCannotUpdateInstance, err.Error())
+	return err
+}
+
+// clone creates the Instance as a clone of the Linode Instance identified by
+// its spec.
+func (e *external) clone(ctx context.Context, m *linodev1alpha1.Instance) (resource.ExternalCreation, error) {
+	src := m.Spec.CloneFrom
+	sourceID := src.LinodeID
+	if src.InstanceRef != nil {
+		source := &linodev1alpha1.Instance{}
+		n := types.NamespacedName{Namespace: m.GetNamespace(), Name: src.InstanceRef.Name}
+		if err := e.kube.Get(ctx, n, source); err != nil {
+			return resource.ExternalCreation{}, errors.Wrap(err, errCloneSource)
+		}
+		if source.Status.Id == 0 {
+			return resource.ExternalCreation{}, errors.New(errCloneNotCreated)
+		}
+		sourceID = source.Status.Id
+	}
+
+	instance, err := e.client.CloneInstance(ctx, sourceID, linodego.InstanceCloneOptions{
+		Label:   m.Spec.Label,
+		Region:  m.Spec.Region,
+		Type:    m.Spec.Type,
+		Disks:   src.Disks,
+		Configs: src.Configs,
+	})
+	if err != nil {
+		return resource.ExternalCreation{}, errors.Wrap(err, errInstanceClone)
+	}
+
+	m.Status.Id = instance.ID
+
+	return resource.ExternalCreation{
+		ConnectionDetails: resource.ConnectionDetails{
+			"ipv6": []byte(instance.IPv6),
+		},
+	}, nil
+}
+
+// observeMigration reports the progress of any migration of the Instance to a
+// new region, and returns true if a migration needs to be initiated.
+func (e *external) observeMigration(ctx context.Context, m *linodev1alpha1.Instance, instance *linodego.Instance) (bool, error) {
+	requested := regionChangeRequested(m, instance)
+	migrating := linodev1alpha1.IsConditionTrue(m.Status.ConditionedStatus, linodev1alpha1.TypeMigration)
+
+	if !requested && !migrating && instance.Status != linodego.InstanceMigrating {
+		return false, nil
+	}
+	if !requested && instance.Status != linodego.InstanceMigrating {
+		m.Status.SetConditions(linodev1alpha1.Migrated(instance.Region))
+		return false, nil
+	}
+
+	if !migrating && instance.Status != linodego.InstanceMigrating {
+		return true, nil
+	}
+
+	event, err := clients.LatestInstanceEvent(ctx, &e.client, instance.ID, clients.ActionLinodeMigrateDatacenter)
+	if err != nil {
+		return false, errors.Wrap(err, errInstanceEvents)
+	}
+	if event != nil && event.Status == linodego.EventFailed {
+		// Retry a failed migration.
+		m.Status.SetConditions(linodev1alpha1.MigrationFailed(m.Spec.Region))
+		return true, nil
+	}
+
+	percentComplete := 0
+	if event != nil {
+		percentComplete = event.PercentComplete
+	}
+	m.Status.SetConditions(linodev1alpha1.Migrating(m.Spec.Region, percentComplete))
+	return false, nil
+}
+
+// regionChangeRequested returns true if the Instance should be migrated to the
+// region in its spec.
+func regionChangeRequested(m *linodev1alpha1.Instance, instance *linodego.Instance) bool {
+	return m.Spec.RegionChangePolicy == linodev1alpha1.RegionChangeMigrate &&
+		m.Spec.Region != "" && m.Spec.Region != instance.Region
+}
+
+// typeChangeRequested returns true if the Instance should be resized to the
+// type in its spec. Only running or offline Instances can be resized.
+func typeChangeRequested(m *linodev1alpha1.Instance, instance *linodego.Instance) bool {
+	if instance.Status != linodego.InstanceRunning && instance.Status != linodego.InstanceOffline {
+		return false
+	}
+	return m.Spec.Type != "" && m.Spec.Type != instance.Type
+}
+
+// rescue boots the Instance into Rescue Mode with the devices requested by its
+// spec, and records that the Instance is in Rescue Mode.
+func (e *external) rescue(ctx context.Context, m *linodev1alpha1.Instance) error {
+	var devices *linodev1alpha1.InstanceDeviceMap
+	if m.Spec.Rescue != nil {
+		devices = m.Spec.Rescue.Devices
+	}
+	deviceMap, err := getDeviceMap(ctx, e.kube, m.GetNamespace(), devices)
+	if err != nil {
+		return errors.Wrap(err, errInstanceRescue)
+	}
+	opts := linodego.InstanceRescueOptions{Devices: deviceMap}
+	if err := e.client.RescueInstance(ctx, m.Status.Id, opts); err != nil {
+		return errors.Wrap(err, errInstanceRescue)
+	}
+	m.Status.Status = linodev1alpha1.InstanceStatusRescue
+	return nil
+}
+
+// isRescueStatus lists the Linode statuses an Instance may report while it
+// remains in Rescue Mode.
+var isRescueStatus = map[linodego.InstanceStatus]bool{
+	linodego.InstanceRunning:   true,
+	linodego.InstanceBooting:   true,
+	linodego.InstanceRebooting: true,
+}
+
+// isOffStatus are the Linode statuses of Instances that are powered off.
+var isOffStatus = map[string]bool{
+	string(linodego.InstanceOffline):     true,
+	linodev1alpha1.InstanceStatusStopped: true,
+}
+
+// bootable returns true if the supplied Instance has a Config to boot.
+func (e *external) bootable(ctx context.Context, m *linodev1alpha1.Instance) (bool, error) {
+	if m.Spec.BootConfigID != 0 {
+		return true, nil
+	}
+	configs, err := e.client.ListInstanceConfigs(ctx, m.Status.Id, nil)
+	if err != nil {
+		return false, errors.Wrap(err, errInstanceConfigs)
+	}
+	return len(configs) > 0, nil
+}
 
-	return errors.Wrap(err, errInstanceDelete)
+// rebootRequested returns true if the Instance carries a reboot-requested-at
+// annotation that has not yet been handled.
+func rebootRequested(m *linodev1alpha1.Instance) bool {
+	requestedAt := m.GetAnnotations()[linodev1alpha1.AnnotationRebootRequestedAt]
+	return requestedAt != "" && requestedAt != m.Status.LastRebootRequestedAt
 }
 
 func createRandomRootPassword() (string, error) {
@@ -0,0 +1,698 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/linode/linodego"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+
+	linodev1alpha1 "github.com/displague/stack-linode/api/v1alpha1"
+)
+
+// A fakeLinodeAPI serves canned responses to requests for the Linode API,
+// keyed by their method and path, and records the requests it receives.
+// Requests without a canned response are answered with 404 Not Found.
+type fakeLinodeAPI struct {
+	mu        sync.Mutex
+	responses map[string]interface{}
+	requests  []string
+}
+
+func (a *fakeLinodeAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+
+	key := r.Method + " " + r.URL.Path
+	a.requests = append(a.requests, key)
+	body, ok := a.responses[key]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"errors": [{"reason": "Not found"}]}`))
+		return
+	}
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// newFakeLinodeAPI starts a fakeLinodeAPI serving the supplied responses, and
+// returns a Linode API client for it. The server is closed when the test ends.
+func newFakeLinodeAPI(t *testing.T, responses map[string]interface{}) (*fakeLinodeAPI, linodego.Client) {
+	a := &fakeLinodeAPI{responses: responses}
+	srv := httptest.NewServer(a)
+	t.Cleanup(srv.Close)
+	lc := linodego.NewClient(srv.Client())
+	lc.SetBaseURL(srv.URL)
+	return a, lc
+}
+
+// page returns a single page of results of a Linode API list endpoint.
+func page(items ...interface{}) map[string]interface{} {
+	return map[string]interface{}{"data": items, "page": 1, "pages": 1, "results": len(items)}
+}
+
+// fakeInstance returns a Linode Instance in us-east with the supplied status.
+func fakeInstance(status string) map[string]interface{} {
+	return map[string]interface{}{
+		"id":     1,
+		"label":  "test",
+		"status": status,
+		"region": "us-east",
+		"type":   "g6-standard-1",
+		"ipv4":   []string{"192.0.2.1"},
+		"ipv6":   "2001:db8::1/128",
+	}
+}
+
+func newInstance(spec linodev1alpha1.InstanceParameters, status linodev1alpha1.InstanceStatus) *linodev1alpha1.Instance {
+	m := &linodev1alpha1.Instance{}
+	m.SetName("test")
+	m.SetNamespace("default")
+	m.Spec.InstanceParameters = spec
+	m.Status = status
+	if m.Status.Id == 0 {
+		m.Status.Id = 1
+	}
+	return m
+}
+
+func TestInstanceObserve(t *testing.T) {
+	bootable := map[string]interface{}{
+		"GET /linode/instances/1/configs": page(map[string]interface{}{"id": 10, "label": "boot"}),
+	}
+	with := func(responses map[string]interface{}, instance map[string]interface{}) map[string]interface{} {
+		r := map[string]interface{}{"GET /linode/instances/1": instance}
+		for k, v := range responses {
+			r[k] = v
+		}
+		return r
+	}
+
+	migrateTo := func(region string) linodev1alpha1.InstanceParameters {
+		return linodev1alpha1.InstanceParameters{Label: "test", Status: "running", Region: region, RegionChangePolicy: linodev1alpha1.RegionChangeMigrate}
+	}
+	migrationEvent := func(status string, percentComplete int) map[string]interface{} {
+		return map[string]interface{}{
+			"GET /account/events": page(map[string]interface{}{"id": 1, "action": "linode_migrate_datacenter", "status": status, "percent_complete": percentComplete}),
+		}
+	}
+	inRegion := func(status, region string) map[string]interface{} {
+		i := fakeInstance(status)
+		i["region"] = region
+		return i
+	}
+
+	cases := map[string]struct {
+		spec       linodev1alpha1.InstanceParameters
+		status     linodev1alpha1.InstanceStatus
+		conditions []runtimev1alpha1.Condition
+		responses  map[string]interface{}
+
+		wantExists     bool
+		wantUpToDate   bool
+		wantConditions map[runtimev1alpha1.ConditionType]corev1.ConditionStatus
+	}{
+		"NotFound": {
+			responses: map[string]interface{}{},
+		},
+		"Running": {
+			spec:         linodev1alpha1.InstanceParameters{Label: "test", Status: "running"},
+			responses:    with(nil, fakeInstance("running")),
+			wantExists:   true,
+			wantUpToDate: true,
+			wantConditions: map[runtimev1alpha1.ConditionType]corev1.ConditionStatus{
+				runtimev1alpha1.TypeReady:     corev1.ConditionTrue,
+				linodev1alpha1.TypePowerState: corev1.ConditionTrue,
+				linodev1alpha1.TypeBootable:   corev1.ConditionUnknown,
+			},
+		},
+		"RunningWantOffline": {
+			spec:       linodev1alpha1.InstanceParameters{Label: "test", Status: "offline"},
+			responses:  with(nil, fakeInstance("running")),
+			wantExists: true,
+			wantConditions: map[runtimev1alpha1.ConditionType]corev1.ConditionStatus{
+				runtimev1alpha1.TypeReady:     corev1.ConditionFalse,
+				linodev1alpha1.TypePowerState: corev1.ConditionTrue,
+			},
+		},
+		"Booting": {
+			spec:       linodev1alpha1.InstanceParameters{Label: "test", Status: "running"},
+			responses:  with(nil, fakeInstance("booting")),
+			wantExists: true,
+			wantConditions: map[runtimev1alpha1.ConditionType]corev1.ConditionStatus{
+				runtimev1alpha1.TypeReady:     corev1.ConditionFalse,
+				linodev1alpha1.TypePowerState: corev1.ConditionUnknown,
+			},
+		},
+		"StoppedWantOffline": {
+			spec:         linodev1alpha1.InstanceParameters{Label: "test", Status: "offline"},
+			responses:    with(nil, fakeInstance(linodev1alpha1.InstanceStatusStopped)),
+			wantExists:   true,
+			wantUpToDate: true,
+			wantConditions: map[runtimev1alpha1.ConditionType]corev1.ConditionStatus{
+				runtimev1alpha1.TypeReady:     corev1.ConditionTrue,
+				linodev1alpha1.TypePowerState: corev1.ConditionFalse,
+			},
+		},
+		"StoppedWantRunning": {
+			spec:       linodev1alpha1.InstanceParameters{Label: "test", Status: "running"},
+			responses:  with(bootable, fakeInstance(linodev1alpha1.InstanceStatusStopped)),
+			wantExists: true,
+			wantConditions: map[runtimev1alpha1.ConditionType]corev1.ConditionStatus{
+				runtimev1alpha1.TypeReady:     corev1.ConditionFalse,
+				linodev1alpha1.TypePowerState: corev1.ConditionFalse,
+				linodev1alpha1.TypeBootable:   corev1.ConditionTrue,
+			},
+		},
+		"Bootable": {
+			spec:       linodev1alpha1.InstanceParameters{Label: "test"},
+			responses:  with(bootable, fakeInstance("offline")),
+			wantExists: true,
+			wantConditions: map[runtimev1alpha1.ConditionType]corev1.ConditionStatus{
+				runtimev1alpha1.TypeReady:   corev1.ConditionFalse,
+				linodev1alpha1.TypeBootable: corev1.ConditionTrue,
+			},
+		},
+		"Unbootable": {
+			spec: linodev1alpha1.InstanceParameters{Label: "test"},
+			responses: with(map[string]interface{}{
+				"GET /linode/instances/1/configs": page(),
+			}, fakeInstance("offline")),
+			wantExists:   true,
+			wantUpToDate: true,
+			wantConditions: map[runtimev1alpha1.ConditionType]corev1.ConditionStatus{
+				linodev1alpha1.TypeBootable: corev1.ConditionFalse,
+			},
+		},
+		"Rescue": {
+			spec:         linodev1alpha1.InstanceParameters{Label: "test", Status: linodev1alpha1.InstanceStatusRescue},
+			status:       linodev1alpha1.InstanceStatus{Status: linodev1alpha1.InstanceStatusRescue},
+			responses:    with(nil, fakeInstance("running")),
+			wantExists:   true,
+			wantUpToDate: true,
+			wantConditions: map[runtimev1alpha1.ConditionType]corev1.ConditionStatus{
+				runtimev1alpha1.TypeReady:     corev1.ConditionTrue,
+				linodev1alpha1.TypePowerState: corev1.ConditionTrue,
+			},
+		},
+		"RescueWantRunning": {
+			spec:       linodev1alpha1.InstanceParameters{Label: "test", Status: "running"},
+			status:     linodev1alpha1.InstanceStatus{Status: linodev1alpha1.InstanceStatusRescue},
+			responses:  with(nil, fakeInstance("running")),
+			wantExists: true,
+			wantConditions: map[runtimev1alpha1.ConditionType]corev1.ConditionStatus{
+				runtimev1alpha1.TypeReady: corev1.ConditionFalse,
+			},
+		},
+		"RescueLeftByShutdown": {
+			spec:         linodev1alpha1.InstanceParameters{Label: "test", Status: "offline"},
+			status:       linodev1alpha1.InstanceStatus{Status: linodev1alpha1.InstanceStatusRescue},
+			responses:    with(nil, fakeInstance("offline")),
+			wantExists:   true,
+			wantUpToDate: true,
+		},
+		"MigrationRequested": {
+			spec:       migrateTo("us-west"),
+			responses:  with(nil, fakeInstance("running")),
+			wantExists: true,
+			wantConditions: map[runtimev1alpha1.ConditionType]corev1.ConditionStatus{
+				linodev1alpha1.TypeMigration: corev1.ConditionUnknown,
+			},
+		},
+		"RegionChangeIgnored": {
+			spec:         linodev1alpha1.InstanceParameters{Label: "test", Status: "running", Region: "us-west"},
+			responses:    with(nil, fakeInstance("running")),
+			wantExists:   true,
+			wantUpToDate: true,
+		},
+		"Migrating": {
+			spec:       migrateTo("us-west"),
+			conditions: []runtimev1alpha1.Condition{linodev1alpha1.Migrating("us-west", 0)},
+			responses:  with(migrationEvent("started", 50), fakeInstance("migrating")),
+			wantExists: true,
+			wantConditions: map[runtimev1alpha1.ConditionType]corev1.ConditionStatus{
+				linodev1alpha1.TypeMigration: corev1.ConditionTrue,
+			},
+		},
+		"MigrationFailed": {
+			spec:       migrateTo("us-west"),
+			conditions: []runtimev1alpha1.Condition{linodev1alpha1.Migrating("us-west", 50)},
+			responses:  with(migrationEvent("failed", 50), fakeInstance("running")),
+			wantExists: true,
+			wantConditions: map[runtimev1alpha1.ConditionType]corev1.ConditionStatus{
+				linodev1alpha1.TypeMigration: corev1.ConditionFalse,
+			},
+		},
+		"Migrated": {
+			spec:         migrateTo("us-west"),
+			conditions:   []runtimev1alpha1.Condition{linodev1alpha1.Migrating("us-west", 50)},
+			responses:    with(nil, inRegion("running", "us-west")),
+			wantExists:   true,
+			wantUpToDate: true,
+			wantConditions: map[runtimev1alpha1.ConditionType]corev1.ConditionStatus{
+				linodev1alpha1.TypeMigration: corev1.ConditionFalse,
+			},
+		},
+		"Renamed": {
+			spec:       linodev1alpha1.InstanceParameters{Label: "renamed", Status: "running"},
+			responses:  with(nil, fakeInstance("running")),
+			wantExists: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, lc := newFakeLinodeAPI(t, tc.responses)
+			e := &external{client: lc, kube: fake.NewFakeClient(), recorder: record.NewFakeRecorder(10)}
+			m := newInstance(tc.spec, tc.status)
+			m.Status.SetConditions(tc.conditions...)
+
+			o, err := e.Observe(context.Background(), m)
+			if err != nil {
+				t.Fatalf("Observe(): %v", err)
+			}
+			if o.ResourceExists != tc.wantExists {
+				t.Errorf("Observe(): want ResourceExists %t, got %t", tc.wantExists, o.ResourceExists)
+			}
+			if o.ResourceUpToDate != tc.wantUpToDate {
+				t.Errorf("Observe(): want ResourceUpToDate %t, got %t", tc.wantUpToDate, o.ResourceUpToDate)
+			}
+			for ct, want := range tc.wantConditions {
+				if got := linodev1alpha1.GetCondition(m.Status.ConditionedStatus, ct).Status; got != want {
+					t.Errorf("Observe(): want %s condition %q, got %q", ct, want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestInstanceUpdate(t *testing.T) {
+	const requestedAt = "2019-10-01T00:00:00Z"
+	rebootRequested := map[string]string{linodev1alpha1.AnnotationRebootRequestedAt: requestedAt}
+
+	cases := map[string]struct {
+		spec        linodev1alpha1.InstanceParameters
+		status      linodev1alpha1.InstanceStatus
+		conditions  []runtimev1alpha1.Condition
+		annotations map[string]string
+		responses   map[string]interface{}
+
+		wantRequests   []string
+		wantLastReboot string
+		wantStatus     string
+	}{
+		"Boot": {
+			spec:   linodev1alpha1.InstanceParameters{Label: "test", Status: "running"},
+			status: linodev1alpha1.InstanceStatus{Status: "offline"},
+			responses: map[string]interface{}{
+				"GET /linode/instances/1":       fakeInstance("offline"),
+				"POST /linode/instances/1/boot": map[string]interface{}{},
+			},
+			wantRequests: []string{"GET /linode/instances/1", "POST /linode/instances/1/boot"},
+		},
+		"BootStopped": {
+			spec:   linodev1alpha1.InstanceParameters{Label: "test", Status: "running"},
+			status: linodev1alpha1.InstanceStatus{Status: linodev1alpha1.InstanceStatusStopped},
+			responses: map[string]interface{}{
+				"GET /linode/instances/1":       fakeInstance(linodev1alpha1.InstanceStatusStopped),
+				"POST /linode/instances/1/boot": map[string]interface{}{},
+			},
+			wantRequests: []string{"GET /linode/instances/1", "POST /linode/instances/1/boot"},
+		},
+		"ShutDown": {
+			spec:   linodev1alpha1.InstanceParameters{Label: "test", Status: "offline"},
+			status: linodev1alpha1.InstanceStatus{Status: "running"},
+			responses: map[string]interface{}{
+				"GET /linode/instances/1":           fakeInstance("running"),
+				"POST /linode/instances/1/shutdown": map[string]interface{}{},
+			},
+			wantRequests: []string{"GET /linode/instances/1", "POST /linode/instances/1/shutdown"},
+		},
+		"StoppedWantOffline": {
+			spec:         linodev1alpha1.InstanceParameters{Label: "test", Status: "offline"},
+			status:       linodev1alpha1.InstanceStatus{Status: linodev1alpha1.InstanceStatusStopped},
+			responses:    map[string]interface{}{"GET /linode/instances/1": fakeInstance(linodev1alpha1.InstanceStatusStopped)},
+			wantRequests: []string{"GET /linode/instances/1"},
+		},
+		"RebootRequested": {
+			spec:        linodev1alpha1.InstanceParameters{Label: "test", Status: "running"},
+			status:      linodev1alpha1.InstanceStatus{Status: "running"},
+			annotations: rebootRequested,
+			responses: map[string]interface{}{
+				"GET /linode/instances/1":         fakeInstance("running"),
+				"POST /linode/instances/1/reboot": map[string]interface{}{},
+			},
+			wantRequests:   []string{"GET /linode/instances/1", "POST /linode/instances/1/reboot"},
+			wantLastReboot: requestedAt,
+		},
+		"RebootHandled": {
+			spec:           linodev1alpha1.InstanceParameters{Label: "test", Status: "running"},
+			status:         linodev1alpha1.InstanceStatus{Status: "running", LastRebootRequestedAt: requestedAt},
+			annotations:    rebootRequested,
+			responses:      map[string]interface{}{"GET /linode/instances/1": fakeInstance("running")},
+			wantRequests:   []string{"GET /linode/instances/1"},
+			wantLastReboot: requestedAt,
+		},
+		"RebootSatisfiedByBoot": {
+			spec:        linodev1alpha1.InstanceParameters{Label: "test", Status: "running"},
+			status:      linodev1alpha1.InstanceStatus{Status: "offline"},
+			annotations: rebootRequested,
+			responses: map[string]interface{}{
+				"GET /linode/instances/1":       fakeInstance("offline"),
+				"POST /linode/instances/1/boot": map[string]interface{}{},
+			},
+			wantRequests:   []string{"GET /linode/instances/1", "POST /linode/instances/1/boot"},
+			wantLastReboot: requestedAt,
+		},
+		"RebootMootWhileOffline": {
+			spec:           linodev1alpha1.InstanceParameters{Label: "test", Status: "offline"},
+			status:         linodev1alpha1.InstanceStatus{Status: "offline"},
+			annotations:    rebootRequested,
+			responses:      map[string]interface{}{"GET /linode/instances/1": fakeInstance("offline")},
+			wantRequests:   []string{"GET /linode/instances/1"},
+			wantLastReboot: requestedAt,
+		},
+		"RebootPendingWhileBusy": {
+			spec:         linodev1alpha1.InstanceParameters{Label: "test", Status: "running"},
+			status:       linodev1alpha1.InstanceStatus{Status: "provisioning"},
+			annotations:  rebootRequested,
+			responses:    map[string]interface{}{"GET /linode/instances/1": fakeInstance("provisioning")},
+			wantRequests: []string{"GET /linode/instances/1"},
+		},
+		"Rescue": {
+			spec:   linodev1alpha1.InstanceParameters{Label: "test", Status: linodev1alpha1.InstanceStatusRescue},
+			status: linodev1alpha1.InstanceStatus{Status: "running"},
+			responses: map[string]interface{}{
+				"GET /linode/instances/1":         fakeInstance("running"),
+				"POST /linode/instances/1/rescue": map[string]interface{}{},
+			},
+			wantRequests: []string{"GET /linode/instances/1", "POST /linode/instances/1/rescue"},
+			wantStatus:   linodev1alpha1.InstanceStatusRescue,
+		},
+		"RebootInRescue": {
+			spec:        linodev1alpha1.InstanceParameters{Label: "test", Status: linodev1alpha1.InstanceStatusRescue},
+			status:      linodev1alpha1.InstanceStatus{Status: linodev1alpha1.InstanceStatusRescue},
+			annotations: rebootRequested,
+			responses: map[string]interface{}{
+				"GET /linode/instances/1":         fakeInstance("running"),
+				"POST /linode/instances/1/rescue": map[string]interface{}{},
+			},
+			wantRequests:   []string{"GET /linode/instances/1", "POST /linode/instances/1/rescue"},
+			wantLastReboot: requestedAt,
+			wantStatus:     linodev1alpha1.InstanceStatusRescue,
+		},
+		"LeaveRescue": {
+			spec:   linodev1alpha1.InstanceParameters{Label: "test", Status: "running"},
+			status: linodev1alpha1.InstanceStatus{Status: linodev1alpha1.InstanceStatusRescue},
+			responses: map[string]interface{}{
+				"GET /linode/instances/1":         fakeInstance("running"),
+				"POST /linode/instances/1/reboot": map[string]interface{}{},
+			},
+			wantRequests: []string{"GET /linode/instances/1", "POST /linode/instances/1/reboot"},
+			wantStatus:   "rebooting",
+		},
+		"Migrate": {
+			spec:   linodev1alpha1.InstanceParameters{Label: "test", Status: "running", Region: "us-west", Type: "g6-standard-2", RegionChangePolicy: linodev1alpha1.RegionChangeMigrate},
+			status: linodev1alpha1.InstanceStatus{Status: "running"},
+			responses: map[string]interface{}{
+				"GET /linode/instances/1":          fakeInstance("running"),
+				"POST /linode/instances/1/migrate": map[string]interface{}{},
+			},
+			wantRequests: []string{"GET /linode/instances/1", "POST /linode/instances/1/migrate"},
+		},
+		"MigrateInProgress": {
+			spec:         linodev1alpha1.InstanceParameters{Label: "test", Status: "running", Region: "us-west", RegionChangePolicy: linodev1alpha1.RegionChangeMigrate},
+			status:       linodev1alpha1.InstanceStatus{Status: "running"},
+			conditions:   []runtimev1alpha1.Condition{linodev1alpha1.Migrating("us-west", 50)},
+			responses:    map[string]interface{}{"GET /linode/instances/1": fakeInstance("running")},
+			wantRequests: []string{"GET /linode/instances/1"},
+		},
+		"Resize": {
+			spec:   linodev1alpha1.InstanceParameters{Label: "test", Status: "running", Type: "g6-standard-2"},
+			status: linodev1alpha1.InstanceStatus{Status: "running"},
+			responses: map[string]interface{}{
+				"GET /linode/instances/1":         fakeInstance("running"),
+				"POST /linode/instances/1/resize": map[string]interface{}{},
+			},
+			wantRequests: []string{"GET /linode/instances/1", "POST /linode/instances/1/resize"},
+		},
+		"Unbootable": {
+			spec:       linodev1alpha1.InstanceParameters{Label: "renamed", Status: "running"},
+			status:     linodev1alpha1.InstanceStatus{Status: "offline"},
+			conditions: []runtimev1alpha1.Condition{linodev1alpha1.Unbootable()},
+			responses: map[string]interface{}{
+				"GET /linode/instances/1": fakeInstance("offline"),
+				"PUT /linode/instances/1": fakeInstance("offline"),
+			},
+			wantRequests: []string{"GET /linode/instances/1", "PUT /linode/instances/1"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			a, lc := newFakeLinodeAPI(t, tc.responses)
+			e := &external{client: lc, kube: fake.NewFakeClient(), recorder: record.NewFakeRecorder(10)}
+			m := newInstance(tc.spec, tc.status)
+			m.Status.SetConditions(tc.conditions...)
+			m.SetAnnotations(tc.annotations)
+
+			if _, err := e.Update(context.Background(), m); err != nil {
+				t.Fatalf("Update(): %v", err)
+			}
+			if !reflect.DeepEqual(a.requests, tc.wantRequests) {
+				t.Errorf("Update(): want requests %q, got %q", tc.wantRequests, a.requests)
+			}
+			if tc.wantStatus != "" && m.Status.Status != tc.wantStatus {
+				t.Errorf("Update(): want status %q, got %q", tc.wantStatus, m.Status.Status)
+			}
+			if m.Status.LastRebootRequestedAt != tc.wantLastReboot {
+				t.Errorf("Update(): want LastRebootRequestedAt %q, got %q", tc.wantLastReboot, m.Status.LastRebootRequestedAt)
+			}
+		})
+	}
+}
+
+func TestInstanceDelete(t *testing.T) {
+	deleted := map[string]interface{}{"DELETE /linode/instances/1": map[string]interface{}{}}
+
+	cases := map[string]struct {
+		spec        linodev1alpha1.InstanceParameters
+		annotations map[string]string
+		responses   map[string]interface{}
+
+		wantErr      bool
+		wantRequests []string
+		wantReason   runtimev1alpha1.ConditionReason
+	}{
+		"Unprotected": {
+			responses:    deleted,
+			wantRequests: []string{"DELETE /linode/instances/1"},
+		},
+		"AlreadyDeleted": {
+			responses:    map[string]interface{}{},
+			wantRequests: []string{"DELETE /linode/instances/1"},
+		},
+		"Protected": {
+			spec:       linodev1alpha1.InstanceParameters{DeletionProtection: true},
+			responses:  deleted,
+			wantErr:    true,
+			wantReason: linodev1alpha1.ReasonDeletionRefused,
+		},
+		"ProtectedByAnnotation": {
+			annotations: map[string]string{linodev1alpha1.AnnotationDeletionProtection: "true"},
+			responses:   deleted,
+			wantErr:     true,
+			wantReason:  linodev1alpha1.ReasonDeletionRefused,
+		},
+		"UnprotectedByAnnotation": {
+			spec:         linodev1alpha1.InstanceParameters{DeletionProtection: true},
+			annotations:  map[string]string{linodev1alpha1.AnnotationDeletionProtection: "false"},
+			responses:    deleted,
+			wantRequests: []string{"DELETE /linode/instances/1"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			a, lc := newFakeLinodeAPI(t, tc.responses)
+			e := &external{client: lc, kube: fake.NewFakeClient(), recorder: record.NewFakeRecorder(10)}
+			m := newInstance(tc.spec, linodev1alpha1.InstanceStatus{})
+			m.SetAnnotations(tc.annotations)
+
+			err := e.Delete(context.Background(), m)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("Delete(): want error %t, got %v", tc.wantErr, err)
+			}
+			if !reflect.DeepEqual(a.requests, tc.wantRequests) {
+				t.Errorf("Delete(): want requests %q, got %q", tc.wantRequests, a.requests)
+			}
+			if tc.wantReason != "" {
+				if got := linodev1alpha1.GetCondition(m.Status.ConditionedStatus, linodev1alpha1.TypeDeletionProtection).Reason; got != tc.wantReason {
+					t.Errorf("Delete(): want DeletionProtection reason %q, got %q", tc.wantReason, got)
+				}
+			}
+		})
+	}
+}
+
+func TestInstanceObserveOnly(t *testing.T) {
+	cases := map[string]struct {
+		spec      linodev1alpha1.InstanceParameters
+		responses map[string]interface{}
+
+		wantExists bool
+		wantID     int
+	}{
+		"Found": {
+			spec: linodev1alpha1.InstanceParameters{Label: "test", Status: "running"},
+			responses: map[string]interface{}{
+				"GET /linode/instances":   page(fakeInstance("running")),
+				"GET /linode/instances/1": fakeInstance("running"),
+			},
+			wantExists: true,
+			wantID:     1,
+		},
+		"FoundNotAsDesired": {
+			spec: linodev1alpha1.InstanceParameters{Label: "test", Status: "offline"},
+			responses: map[string]interface{}{
+				"GET /linode/instances":   page(fakeInstance("running")),
+				"GET /linode/instances/1": fakeInstance("running"),
+			},
+			wantExists: true,
+			wantID:     1,
+		},
+		"Missing": {
+			spec:      linodev1alpha1.InstanceParameters{Label: "test", Status: "running"},
+			responses: map[string]interface{}{"GET /linode/instances": page()},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			a, lc := newFakeLinodeAPI(t, tc.responses)
+			e := &managementPolicyExternal{
+				ExternalClient: &external{client: lc, kube: fake.NewFakeClient(), recorder: record.NewFakeRecorder(10)},
+				observeOnly:    true,
+			}
+			m := newInstance(tc.spec, linodev1alpha1.InstanceStatus{})
+			m.Spec.ManagementPolicy = linodev1alpha1.ManagementPolicyObserveOnly
+			m.Status.Id = 0
+
+			o, err := e.Observe(context.Background(), m)
+			if err != nil {
+				t.Fatalf("Observe(): %v", err)
+			}
+			if o.ResourceExists != tc.wantExists || (tc.wantExists && !o.ResourceUpToDate) {
+				t.Errorf("Observe(): want ResourceExists %t and up to date, got %+v", tc.wantExists, o)
+			}
+			if m.Status.Id != tc.wantID {
+				t.Errorf("Observe(): want Id %d, got %d", tc.wantID, m.Status.Id)
+			}
+
+			// Instances that are only observed are never created or changed.
+			requests := len(a.requests)
+			if _, err := e.Create(context.Background(), m); err == nil {
+				t.Errorf("Create(): want error creating an Instance that is only observed, got none")
+			}
+			if _, err := e.Update(context.Background(), m); err != nil {
+				t.Errorf("Update(): %v", err)
+			}
+			if len(a.requests) != requests {
+				t.Errorf("Create() and Update(): want no requests, got %q", a.requests[requests:])
+			}
+		})
+	}
+}
+
+func TestInstanceClone(t *testing.T) {
+	clone := fakeInstance("provisioning")
+	clone["id"] = 2
+
+	source := func(id int) *linodev1alpha1.Instance {
+		m := &linodev1alpha1.Instance{}
+		m.SetNamespace("default")
+		m.SetName("source")
+		m.Status.Id = id
+		return m
+	}
+
+	cases := map[string]struct {
+		from     linodev1alpha1.InstanceCloneSource
+		existing []runtime.Object
+
+		wantErr      bool
+		wantRequests []string
+		wantID       int
+	}{
+		"LinodeID": {
+			from:         linodev1alpha1.InstanceCloneSource{LinodeID: 5},
+			wantRequests: []string{"POST /linode/instances/5/clone"},
+			wantID:       2,
+		},
+		"InstanceRef": {
+			from:         linodev1alpha1.InstanceCloneSource{InstanceRef: &corev1.LocalObjectReference{Name: "source"}},
+			existing:     []runtime.Object{source(7)},
+			wantRequests: []string{"POST /linode/instances/7/clone"},
+			wantID:       2,
+		},
+		"InstanceRefNotCreated": {
+			from:     linodev1alpha1.InstanceCloneSource{InstanceRef: &corev1.LocalObjectReference{Name: "source"}},
+			existing: []runtime.Object{source(0)},
+			wantErr:  true,
+		},
+		"InstanceRefMissing": {
+			from:    linodev1alpha1.InstanceCloneSource{InstanceRef: &corev1.LocalObjectReference{Name: "source"}},
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			a, lc := newFakeLinodeAPI(t, map[string]interface{}{
+				"POST /linode/instances/5/clone": clone,
+				"POST /linode/instances/7/clone": clone,
+			})
+			e := &external{client: lc, kube: newFakeKubeClient(t, tc.existing...), recorder: record.NewFakeRecorder(10)}
+			from := tc.from
+			m := newInstance(linodev1alpha1.InstanceParameters{Label: "test", Region: "us-east", Type: "g6-standard-1", CloneFrom: &from}, linodev1alpha1.InstanceStatus{})
+			m.Status.Id = 0
+
+			_, err := e.Create(context.Background(), m)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("Create(): want error %t, got %v", tc.wantErr, err)
+			}
+			if !reflect.DeepEqual(a.requests, tc.wantRequests) {
+				t.Errorf("Create(): want requests %q, got %q", tc.wantRequests, a.requests)
+			}
+			if m.Status.Id != tc.wantID {
+				t.Errorf("Create(): want Id %d, got %d", tc.wantID, m.Status.Id)
+			}
+		})
+	}
+}
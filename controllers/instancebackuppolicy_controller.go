@@ -0,0 +1,225 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/linode/linodego"
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+
+	linodev1alpha1 "github.com/displague/stack-linode/api/v1alpha1"
+	"github.com/displague/stack-linode/catalog"
+	"github.com/displague/stack-linode/clients"
+)
+
+const (
+	errNotInstanceBackupPolicy = "managed resource is not an InstanceBackupPolicy"
+	errBackupsEnable           = "cannot enable Instance Backups"
+	errBackupsSchedule         = "cannot schedule Instance Backups"
+	errBackupsCancel           = "cannot cancel Instance Backups"
+)
+
+// backupScheduling is the Backup schedule day or window that lets Linode
+// choose when Backups are taken.
+const backupScheduling = "Scheduling"
+
+// InstanceBackupPolicyController is responsible for adding the InstanceBackupPolicy
+// controller and its corresponding reconciler to the manager with any runtime configuration.
+type InstanceBackupPolicyController struct {
+	Options Options
+}
+
+var (
+	instanceBackupPolicyLog = ctrl.Log.WithName("instancebackuppolicy.controller")
+)
+
+// SetupWithManager creates a new InstanceBackupPolicy Controller and adds it
+// to the Manager with default RBAC. The Manager will set fields on the
+// Controller and start it when the Manager is Started.
+func (c *InstanceBackupPolicyController) SetupWithManager(mgr ctrl.Manager) error {
+	r := resource.NewManagedReconciler(mgr,
+		resource.ManagedKind(linodev1alpha1.InstanceBackupPolicyGroupVersionKind),
+		resource.WithLongWait(c.Options.pollInterval()),
+		resource.WithExternalConnecter(withTracing(linodev1alpha1.InstanceBackupPolicyKind, withManagementPolicy(linodev1alpha1.InstanceBackupPolicyKind, &instanceBackupPolicyConnecter{client: mgr.GetClient(), catalog: c.Options.Catalog}))))
+
+	name := strings.ToLower(fmt.Sprintf("%s.%s", linodev1alpha1.InstanceBackupPolicyKind, linodev1alpha1.Group))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(c.Options.controllerOptions(linodev1alpha1.InstanceBackupPolicyKind)).
+		For(&linodev1alpha1.InstanceBackupPolicy{}).
+		Complete(withPause(mgr.GetClient(), &linodev1alpha1.InstanceBackupPolicy{}, r))
+}
+
+type instanceBackupPolicyConnecter struct {
+	client      client.Client
+	catalog     *catalog.Catalog
+	newClientFn func(credentials []byte) (linodego.Client, error)
+}
+
+// Connect to the supplied resource.Managed (presumed to be an
+// InstanceBackupPolicy) by using the Provider it references to create a new
+// Linode API client.
+func (c *instanceBackupPolicyConnecter) Connect(ctx context.Context, mg resource.Managed) (resource.ExternalClient, error) {
+	m, ok := mg.(*linodev1alpha1.InstanceBackupPolicy)
+	if !ok {
+		return nil, errors.New(errNotInstanceBackupPolicy)
+	}
+
+	client, err := connectLinode(ctx, c.client, m, c.newClientFn)
+	if err != nil {
+		return nil, err
+	}
+	return &instanceBackupPolicyExternal{client: client, kube: c.client, catalog: c.catalog}, nil
+}
+
+type instanceBackupPolicyExternal struct {
+	client  linodego.Client
+	kube    client.Client
+	catalog *catalog.Catalog
+}
+
+// Observe the Backups of the Linode Instance the policy applies to. The policy
+// exists while Backups are enabled for the Instance.
+func (e *instanceBackupPolicyExternal) Observe(ctx context.Context, mg resource.Managed) (resource.ExternalObservation, error) {
+	m, ok := mg.(*linodev1alpha1.InstanceBackupPolicy)
+	if !ok {
+		return resource.ExternalObservation{}, errors.New(errNotInstanceBackupPolicy)
+	}
+
+	if m.Status.InstanceID == 0 {
+		return resource.ExternalObservation{}, nil
+	}
+
+	ctx, log := managedLogger(ctx, instanceBackupPolicyLog, m)
+	instance, err := e.client.GetInstance(ctx, m.Status.InstanceID)
+	if clients.IsNotFound(err) {
+		return resource.ExternalObservation{}, nil
+	}
+	if err != nil {
+		return resource.ExternalObservation{}, errors.Wrap(err, errInstanceGet)
+	}
+
+	m.Status.Enabled = instance.Backups != nil && instance.Backups.Enabled
+	if !m.Status.Enabled {
+		m.Status.EstimatedMonthlyCost = ""
+		return resource.ExternalObservation{}, nil
+	}
+
+	log.V(1).Info("Observe", "instanceId", m.Status.InstanceID, "day", instance.Backups.Schedule.Day, "window", instance.Backups.Schedule.Window)
+
+	m.Status.Day = instance.Backups.Schedule.Day
+	m.Status.Window = instance.Backups.Schedule.Window
+	m.Status.EstimatedMonthlyCost = backupsMonthlyCost(e.catalog, instance.Type)
+	m.Status.SetConditions(runtimev1alpha1.Available())
+	resource.SetBindable(m)
+
+	return resource.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: backupScheduleUpToDate(m),
+	}, nil
+}
+
+// Create the policy by enabling Backups for the Linode Instance and scheduling
+// them.
+func (e *instanceBackupPolicyExternal) Create(ctx context.Context, mg resource.Managed) (resource.ExternalCreation, error) {
+	m, ok := mg.(*linodev1alpha1.InstanceBackupPolicy)
+	if !ok {
+		return resource.ExternalCreation{}, errors.New(errNotInstanceBackupPolicy)
+	}
+
+	m.Status.SetConditions(runtimev1alpha1.Creating())
+
+	instanceID, err := getInstanceID(ctx, e.kube, m.GetNamespace(), m.Spec.InstanceRef, m.Spec.InstanceID)
+	if err != nil {
+		return resource.ExternalCreation{}, errors.Wrap(err, errBackupsEnable)
+	}
+
+	if err := e.client.EnableInstanceBackups(ctx, instanceID); err != nil {
+		return resource.ExternalCreation{}, errors.Wrap(err, errBackupsEnable)
+	}
+	m.Status.InstanceID = instanceID
+	m.Status.Enabled = true
+
+	if m.Spec.Schedule != nil {
+		if err := e.schedule(ctx, m); err != nil {
+			return resource.ExternalCreation{}, err
+		}
+	}
+
+	return resource.ExternalCreation{}, nil
+}
+
+// Update the Backup schedule of the Linode Instance to match the policy.
+func (e *instanceBackupPolicyExternal) Update(ctx context.Context, mg resource.Managed) (resource.ExternalUpdate, error) {
+	m, ok := mg.(*linodev1alpha1.InstanceBackupPolicy)
+	if !ok {
+		return resource.ExternalUpdate{}, errors.New(errNotInstanceBackupPolicy)
+	}
+
+	return resource.ExternalUpdate{}, e.schedule(ctx, m)
+}
+
+// Delete the policy by cancelling Backups for the Linode Instance. Cancelling
+// Backups removes all existing Backups of the Instance.
+func (e *instanceBackupPolicyExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	m, ok := mg.(*linodev1alpha1.InstanceBackupPolicy)
+	if !ok {
+		return errors.New(errNotInstanceBackupPolicy)
+	}
+
+	m.SetConditions(runtimev1alpha1.Deleting())
+	err := e.client.CancelInstanceBackups(ctx, m.Status.InstanceID)
+	if clients.IsNotFound(err) {
+		return nil
+	}
+	return errors.Wrap(err, errBackupsCancel)
+}
+
+func (e *instanceBackupPolicyExternal) schedule(ctx context.Context, m *linodev1alpha1.InstanceBackupPolicy) error {
+	schedule := clients.InstanceBackupSchedule{}
+	if m.Spec.Schedule != nil {
+		schedule.Day = m.Spec.Schedule.Day
+		schedule.Window = m.Spec.Schedule.Window
+	}
+	return errors.Wrap(clients.UpdateInstanceBackupSchedule(ctx, &e.client, m.Status.InstanceID, schedule), errBackupsSchedule)
+}
+
+// backupScheduleUpToDate returns true if the observed Backup schedule matches
+// the schedule of the policy.
+func backupScheduleUpToDate(m *linodev1alpha1.InstanceBackupPolicy) bool {
+	s := m.Spec.Schedule
+	if s == nil {
+		return true
+	}
+	return backupScheduled(s.Day, m.Status.Day) && backupScheduled(s.Window, m.Status.Window)
+}
+
+// backupScheduled returns true if the observed day or window of a Backup
+// schedule satisfies the desired one. Linode chooses unset and "Scheduling"
+// days and windows, so any observed value satisfies them.
+func backupScheduled(desired, observed string) bool {
+	return desired == "" || desired == backupScheduling || desired == observed
+}
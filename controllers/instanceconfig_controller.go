@@ -0,0 +1,334 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/linode/linodego"
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+
+	linodev1alpha1 "github.com/displague/stack-linode/api/v1alpha1"
+	"github.com/displague/stack-linode/clients"
+)
+
+const (
+	errNotInstanceConfig = "managed resource is not an InstanceConfig"
+	errConfigGet         = "cannot get InstanceConfig"
+	errConfigCreate      = "cannot create InstanceConfig"
+	errConfigUpdate      = "cannot update InstanceConfig"
+	errConfigDelete      = "cannot delete InstanceConfig"
+)
+
+// defaultInstanceConfigHelpers are the helpers the Linode API enables for new
+// Instance Configs.
+var defaultInstanceConfigHelpers = linodego.InstanceConfigHelpers{
+	UpdateDBDisabled:  true,
+	Distro:            true,
+	ModulesDep:        true,
+	Network:           true,
+	DevTmpFsAutomount: true,
+}
+
+// InstanceConfigController is responsible for adding the InstanceConfig
+// controller and its corresponding reconciler to the manager with any runtime configuration.
+type InstanceConfigController struct {
+	Options Options
+}
+
+// SetupWithManager creates a new InstanceConfig Controller and adds it to the
+// Manager with default RBAC. The Manager will set fields on the Controller and
+// start it when the Manager is Started.
+func (c *InstanceConfigController) SetupWithManager(mgr ctrl.Manager) error {
+	r := resource.NewManagedReconciler(mgr,
+		resource.ManagedKind(linodev1alpha1.InstanceConfigGroupVersionKind),
+		resource.WithLongWait(c.Options.pollInterval()),
+		resource.WithExternalConnecter(withTracing(linodev1alpha1.InstanceConfigKind, withManagementPolicy(linodev1alpha1.InstanceConfigKind, &instanceConfigConnecter{client: mgr.GetClient()}))))
+
+	name := strings.ToLower(fmt.Sprintf("%s.%s", linodev1alpha1.InstanceConfigKind, linodev1alpha1.Group))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(c.Options.controllerOptions(linodev1alpha1.InstanceConfigKind)).
+		For(&linodev1alpha1.InstanceConfig{}).
+		Complete(withPause(mgr.GetClient(), &linodev1alpha1.InstanceConfig{}, r))
+}
+
+type instanceConfigConnecter struct {
+	client      client.Client
+	newClientFn func(credentials []byte) (linodego.Client, error)
+}
+
+// Connect to the supplied resource.Managed (presumed to be an
+// InstanceConfig) by using the Provider it references to create a new
+// Linode API client.
+func (c *instanceConfigConnecter) Connect(ctx context.Context, mg resource.Managed) (resource.ExternalClient, error) {
+	m, ok := mg.(*linodev1alpha1.InstanceConfig)
+	if !ok {
+		return nil, errors.New(errNotInstanceConfig)
+	}
+
+	client, err := connectLinode(ctx, c.client, m, c.newClientFn)
+	if err != nil {
+		return nil, err
+	}
+	return &instanceConfigExternal{client: client, kube: c.client}, nil
+}
+
+type instanceConfigExternal struct {
+	client linodego.Client
+	kube   client.Client
+}
+
+// Observe the existing Linode Instance Config, if any.
+func (e *instanceConfigExternal) Observe(ctx context.Context, mg resource.Managed) (resource.ExternalObservation, error) {
+	m, ok := mg.(*linodev1alpha1.InstanceConfig)
+	if !ok {
+		return resource.ExternalObservation{}, errors.New(errNotInstanceConfig)
+	}
+
+	if m.Status.Id == 0 {
+		return resource.ExternalObservation{}, nil
+	}
+
+	config, err := clients.GetInstanceConfig(ctx, &e.client, m.Status.InstanceID, m.Status.Id)
+	if clients.IsNotFound(err) {
+		return resource.ExternalObservation{}, nil
+	}
+	if err != nil {
+		return resource.ExternalObservation{}, errors.Wrap(err, errConfigGet)
+	}
+
+	m.Status.Label = config.Label
+	m.Status.Kernel = config.Kernel
+	m.Status.SetConditions(runtimev1alpha1.Available())
+	resource.SetBindable(m)
+
+	upToDate, err := e.isUpToDate(ctx, m, config)
+	if err != nil {
+		return resource.ExternalObservation{}, errors.Wrap(err, errConfigGet)
+	}
+
+	return resource.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: upToDate,
+	}, nil
+}
+
+// Create a new Linode Instance Config on the Instance referenced by the
+// InstanceConfig.
+func (e *instanceConfigExternal) Create(ctx context.Context, mg resource.Managed) (resource.ExternalCreation, error) {
+	m, ok := mg.(*linodev1alpha1.InstanceConfig)
+	if !ok {
+		return resource.ExternalCreation{}, errors.New(errNotInstanceConfig)
+	}
+
+	m.Status.SetConditions(runtimev1alpha1.Creating())
+
+	instanceID, err := getInstanceID(ctx, e.kube, m.GetNamespace(), m.Spec.InstanceRef, m.Spec.InstanceID)
+	if err != nil {
+		return resource.ExternalCreation{}, errors.Wrap(err, errConfigCreate)
+	}
+	devices, err := getDeviceMap(ctx, e.kube, m.GetNamespace(), m.Spec.Devices)
+	if err != nil {
+		return resource.ExternalCreation{}, errors.Wrap(err, errConfigCreate)
+	}
+
+	opts := linodego.InstanceConfigCreateOptions{
+		Label:       instanceConfigLabel(m),
+		Comments:    m.Spec.Comments,
+		Devices:     devices,
+		Helpers:     instanceConfigHelpers(m.Spec.Helpers, defaultInstanceConfigHelpers),
+		MemoryLimit: m.Spec.MemoryLimit,
+		Kernel:      m.Spec.Kernel,
+		RunLevel:    m.Spec.RunLevel,
+		VirtMode:    m.Spec.VirtMode,
+	}
+	if m.Spec.RootDevice != "" {
+		opts.RootDevice = &m.Spec.RootDevice
+	}
+
+	config, err := clients.CreateInstanceConfig(ctx, &e.client, instanceID, opts, instanceConfigInterfaces(m.Spec.Interfaces))
+	if err != nil {
+		return resource.ExternalCreation{}, errors.Wrap(err, errConfigCreate)
+	}
+
+	m.Status.Id = config.ID
+	m.Status.InstanceID = instanceID
+
+	return resource.ExternalCreation{}, nil
+}
+
+// Update the Linode Instance Config to match the InstanceConfig.
+func (e *instanceConfigExternal) Update(ctx context.Context, mg resource.Managed) (resource.ExternalUpdate, error) {
+	m, ok := mg.(*linodev1alpha1.InstanceConfig)
+	if !ok {
+		return resource.ExternalUpdate{}, errors.New(errNotInstanceConfig)
+	}
+
+	config, err := clients.GetInstanceConfig(ctx, &e.client, m.Status.InstanceID, m.Status.Id)
+	if err != nil {
+		return resource.ExternalUpdate{}, errors.Wrap(err, errConfigGet)
+	}
+
+	opts := linodego.InstanceConfigUpdateOptions{
+		Label:       instanceConfigLabel(m),
+		Comments:    m.Spec.Comments,
+		Helpers:     instanceConfigHelpers(m.Spec.Helpers, observedInstanceConfigHelpers(config)),
+		MemoryLimit: m.Spec.MemoryLimit,
+		Kernel:      m.Spec.Kernel,
+		InitRD:      config.InitRD,
+		RootDevice:  m.Spec.RootDevice,
+		RunLevel:    m.Spec.RunLevel,
+		VirtMode:    m.Spec.VirtMode,
+	}
+	if m.Spec.Devices != nil {
+		devices, err := getDeviceMap(ctx, e.kube, m.GetNamespace(), m.Spec.Devices)
+		if err != nil {
+			return resource.ExternalUpdate{}, errors.Wrap(err, errConfigUpdate)
+		}
+		opts.Devices = &devices
+	}
+
+	// Interfaces are left as they are unless the InstanceConfig specifies them.
+	interfaces := config.Interfaces
+	if m.Spec.Interfaces != nil {
+		interfaces = instanceConfigInterfaces(m.Spec.Interfaces)
+	}
+
+	_, err = clients.UpdateInstanceConfig(ctx, &e.client, m.Status.InstanceID, m.Status.Id, opts, interfaces)
+	return resource.ExternalUpdate{}, errors.Wrap(err, errConfigUpdate)
+}
+
+// Delete the Linode Instance Config.
+func (e *instanceConfigExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	m, ok := mg.(*linodev1alpha1.InstanceConfig)
+	if !ok {
+		return errors.New(errNotInstanceConfig)
+	}
+
+	m.SetConditions(runtimev1alpha1.Deleting())
+	err := e.client.DeleteInstanceConfig(ctx, m.Status.InstanceID, m.Status.Id)
+	if clients.IsNotFound(err) {
+		return nil
+	}
+	return errors.Wrap(err, errConfigDelete)
+}
+
+// isUpToDate returns true if the observed Linode Instance Config matches the
+// InstanceConfig. Optional fields that are not set in the InstanceConfig are
+// not compared.
+func (e *instanceConfigExternal) isUpToDate(ctx context.Context, m *linodev1alpha1.InstanceConfig, config *clients.InstanceConfig) (bool, error) {
+	optional := func(want, got string) bool { return want == "" || want == got }
+
+	if config.Label != instanceConfigLabel(m) ||
+		config.Comments != m.Spec.Comments ||
+		config.MemoryLimit != m.Spec.MemoryLimit ||
+		!optional(m.Spec.Kernel, config.Kernel) ||
+		!optional(m.Spec.RootDevice, config.RootDevice) ||
+		!optional(m.Spec.RunLevel, config.RunLevel) ||
+		!optional(m.Spec.VirtMode, config.VirtMode) {
+		return false, nil
+	}
+
+	observed := observedInstanceConfigHelpers(config)
+	if m.Spec.Helpers != nil && *instanceConfigHelpers(m.Spec.Helpers, observed) != observed {
+		return false, nil
+	}
+
+	if m.Spec.Interfaces != nil {
+		want := instanceConfigInterfaces(m.Spec.Interfaces)
+		if (len(want) != 0 || len(config.Interfaces) != 0) && !reflect.DeepEqual(want, config.Interfaces) {
+			return false, nil
+		}
+	}
+
+	if m.Spec.Devices == nil {
+		return true, nil
+	}
+	devices, err := getDeviceMap(ctx, e.kube, m.GetNamespace(), m.Spec.Devices)
+	if err != nil {
+		return false, err
+	}
+	observedDevices := linodego.InstanceConfigDeviceMap{}
+	if config.Devices != nil {
+		observedDevices = *config.Devices
+	}
+	return reflect.DeepEqual(devices, observedDevices), nil
+}
+
+// instanceConfigLabel returns the label of the Linode Instance Config,
+// defaulting to the name of the InstanceConfig.
+func instanceConfigLabel(m *linodev1alpha1.InstanceConfig) string {
+	if m.Spec.Label != "" {
+		return m.Spec.Label
+	}
+	return m.GetName()
+}
+
+// instanceConfigHelpers applies the supplied helpers to the base helpers. It
+// returns nil if no helpers are supplied.
+func instanceConfigHelpers(h *linodev1alpha1.InstanceConfigHelpers, base linodego.InstanceConfigHelpers) *linodego.InstanceConfigHelpers {
+	if h == nil {
+		return nil
+	}
+	set := func(in *bool, out *bool) {
+		if in != nil {
+			*out = *in
+		}
+	}
+	set(h.UpdateDBDisabled, &base.UpdateDBDisabled)
+	set(h.Distro, &base.Distro)
+	set(h.ModulesDep, &base.ModulesDep)
+	set(h.Network, &base.Network)
+	set(h.DevTmpFsAutomount, &base.DevTmpFsAutomount)
+	return &base
+}
+
+// observedInstanceConfigHelpers returns the helpers of the supplied Linode
+// Instance Config.
+func observedInstanceConfigHelpers(config *clients.InstanceConfig) linodego.InstanceConfigHelpers {
+	if config.Helpers == nil {
+		return defaultInstanceConfigHelpers
+	}
+	return *config.Helpers
+}
+
+// instanceConfigInterfaces converts InstanceConfigInterfaces to the network
+// interfaces expected by the Linode API.
+func instanceConfigInterfaces(in []linodev1alpha1.InstanceConfigInterface) []clients.InstanceConfigInterface {
+	if in == nil {
+		return nil
+	}
+	out := make([]clients.InstanceConfigInterface, len(in))
+	for i, iface := range in {
+		out[i] = clients.InstanceConfigInterface{
+			Purpose:     iface.Purpose,
+			Label:       iface.Label,
+			IPAMAddress: iface.IPAMAddress,
+		}
+	}
+	return out
+}
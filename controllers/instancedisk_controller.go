@@ -0,0 +1,248 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/linode/linodego"
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+
+	linodev1alpha1 "github.com/displague/stack-linode/api/v1alpha1"
+	"github.com/displague/stack-linode/catalog"
+	"github.com/displague/stack-linode/clients"
+)
+
+const (
+	errNotInstanceDisk = "managed resource is not an InstanceDisk"
+	errDiskGet         = "cannot get InstanceDisk"
+	errDiskCreate      = "cannot create InstanceDisk"
+	errDiskRename      = "cannot rename InstanceDisk"
+	errDiskResize      = "cannot resize InstanceDisk"
+	errDiskDelete      = "cannot delete InstanceDisk"
+)
+
+// InstanceDiskController is responsible for adding the InstanceDisk
+// controller and its corresponding reconciler to the manager with any runtime configuration.
+type InstanceDiskController struct {
+	Options Options
+}
+
+var (
+	instanceDiskLog = ctrl.Log.WithName("instancedisk.controller")
+)
+
+// SetupWithManager creates a new InstanceDisk Controller and adds it to the
+// Manager with default RBAC. The Manager will set fields on the Controller and
+// start it when the Manager is Started.
+func (c *InstanceDiskController) SetupWithManager(mgr ctrl.Manager) error {
+	r := resource.NewManagedReconciler(mgr,
+		resource.ManagedKind(linodev1alpha1.InstanceDiskGroupVersionKind),
+		resource.WithLongWait(c.Options.pollInterval()),
+		resource.WithExternalConnecter(withTracing(linodev1alpha1.InstanceDiskKind, withManagementPolicy(linodev1alpha1.InstanceDiskKind, &instanceDiskConnecter{client: mgr.GetClient(), catalog: c.Options.Catalog}))))
+
+	name := strings.ToLower(fmt.Sprintf("%s.%s", linodev1alpha1.InstanceDiskKind, linodev1alpha1.Group))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(c.Options.controllerOptions(linodev1alpha1.InstanceDiskKind)).
+		For(&linodev1alpha1.InstanceDisk{}).
+		Complete(withPause(mgr.GetClient(), &linodev1alpha1.InstanceDisk{}, r))
+}
+
+type instanceDiskConnecter struct {
+	client      client.Client
+	catalog     *catalog.Catalog
+	newClientFn func(credentials []byte) (linodego.Client, error)
+}
+
+// Connect to the supplied resource.Managed (presumed to be an
+// InstanceDisk) by using the Provider it references to create a new
+// Linode API client.
+func (c *instanceDiskConnecter) Connect(ctx context.Context, mg resource.Managed) (resource.ExternalClient, error) {
+	m, ok := mg.(*linodev1alpha1.InstanceDisk)
+	if !ok {
+		return nil, errors.New(errNotInstanceDisk)
+	}
+
+	client, err := connectLinode(ctx, c.client, m, c.newClientFn)
+	if err != nil {
+		return nil, err
+	}
+	return &instanceDiskExternal{client: client, kube: c.client, catalog: c.catalog}, nil
+}
+
+type instanceDiskExternal struct {
+	client  linodego.Client
+	kube    client.Client
+	catalog *catalog.Catalog
+}
+
+// Observe the existing Linode Instance Disk, if any.
+func (e *instanceDiskExternal) Observe(ctx context.Context, mg resource.Managed) (resource.ExternalObservation, error) {
+	m, ok := mg.(*linodev1alpha1.InstanceDisk)
+	if !ok {
+		return resource.ExternalObservation{}, errors.New(errNotInstanceDisk)
+	}
+
+	if m.Status.Id == 0 {
+		return resource.ExternalObservation{}, nil
+	}
+
+	ctx, log := managedLogger(ctx, instanceDiskLog, m)
+	disk, err := e.client.GetInstanceDisk(ctx, m.Status.InstanceID, m.Status.Id)
+	if clients.IsNotFound(err) {
+		return resource.ExternalObservation{}, nil
+	}
+	if err != nil {
+		return resource.ExternalObservation{}, errors.Wrap(err, errDiskGet)
+	}
+
+	log.V(1).Info("Observe", "instanceId", m.Status.InstanceID, "diskId", m.Status.Id, "status", disk.Status)
+
+	m.Status.Status = string(disk.Status)
+	m.Status.Label = disk.Label
+	m.Status.Size = disk.Size
+	m.Status.Filesystem = string(disk.Filesystem)
+
+	switch disk.Status {
+	case linodego.DiskReady:
+		m.Status.SetConditions(runtimev1alpha1.Available())
+		resource.SetBindable(m)
+	case linodego.DiskNotReady:
+		m.Status.SetConditions(runtimev1alpha1.Creating())
+	case linodego.DiskDeleting:
+		m.Status.SetConditions(runtimev1alpha1.Deleting())
+	}
+
+	return resource.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: disk.Label == instanceDiskLabel(m) && disk.Size == m.Spec.Size,
+	}, nil
+}
+
+// Create a new Linode Instance Disk on the Instance referenced by the
+// InstanceDisk.
+func (e *instanceDiskExternal) Create(ctx context.Context, mg resource.Managed) (resource.ExternalCreation, error) {
+	m, ok := mg.(*linodev1alpha1.InstanceDisk)
+	if !ok {
+		return resource.ExternalCreation{}, errors.New(errNotInstanceDisk)
+	}
+
+	m.Status.SetConditions(runtimev1alpha1.Creating())
+
+	if err := validateImage(e.catalog, m.Spec.Image); err != nil {
+		return resource.ExternalCreation{}, errors.Wrap(err, errDiskCreate)
+	}
+
+	instanceID, err := getInstanceID(ctx, e.kube, m.GetNamespace(), m.Spec.InstanceRef, m.Spec.InstanceID)
+	if err != nil {
+		return resource.ExternalCreation{}, errors.Wrap(err, errDiskCreate)
+	}
+
+	image, err := getImageID(ctx, e.kube, m.GetNamespace(), m.Spec.ImageRef, m.Spec.Image)
+	if err != nil {
+		return resource.ExternalCreation{}, errors.Wrap(err, errDiskCreate)
+	}
+
+	opts := linodego.InstanceDiskCreateOptions{
+		Label:           instanceDiskLabel(m),
+		Size:            m.Spec.Size,
+		Filesystem:      m.Spec.Filesystem,
+		Image:           image,
+		AuthorizedUsers: m.Spec.AuthorizedUsers,
+	}
+	details := resource.ConnectionDetails{}
+	if image != "" {
+		keys, err := getAuthorizedKeys(ctx, e.kube, m.GetNamespace(), m.Spec.SSHKeyRefs)
+		if err != nil {
+			return resource.ExternalCreation{}, errors.Wrap(err, errDiskCreate)
+		}
+		opts.AuthorizedKeys = keys
+
+		rootPass, err := createRandomRootPassword()
+		if err != nil {
+			return resource.ExternalCreation{}, errors.Wrap(err, errDiskCreate)
+		}
+		opts.RootPass = rootPass
+		details["rootPass"] = []byte(rootPass)
+	}
+
+	disk, err := e.client.CreateInstanceDisk(ctx, instanceID, opts)
+	if err != nil {
+		return resource.ExternalCreation{}, errors.Wrap(err, errDiskCreate)
+	}
+
+	m.Status.Id = disk.ID
+	m.Status.InstanceID = instanceID
+
+	return resource.ExternalCreation{ConnectionDetails: details}, nil
+}
+
+// Update the Linode Instance Disk, renaming or resizing it to match the
+// InstanceDisk.
+func (e *instanceDiskExternal) Update(ctx context.Context, mg resource.Managed) (resource.ExternalUpdate, error) {
+	m, ok := mg.(*linodev1alpha1.InstanceDisk)
+	if !ok {
+		return resource.ExternalUpdate{}, errors.New(errNotInstanceDisk)
+	}
+
+	if m.Status.Label != instanceDiskLabel(m) {
+		if _, err := e.client.RenameInstanceDisk(ctx, m.Status.InstanceID, m.Status.Id, instanceDiskLabel(m)); err != nil {
+			return resource.ExternalUpdate{}, errors.Wrap(err, errDiskRename)
+		}
+	}
+
+	if m.Status.Size != m.Spec.Size {
+		if err := e.client.ResizeInstanceDisk(ctx, m.Status.InstanceID, m.Status.Id, m.Spec.Size); err != nil {
+			return resource.ExternalUpdate{}, errors.Wrap(err, errDiskResize)
+		}
+	}
+
+	return resource.ExternalUpdate{}, nil
+}
+
+// Delete the Linode Instance Disk.
+func (e *instanceDiskExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	m, ok := mg.(*linodev1alpha1.InstanceDisk)
+	if !ok {
+		return errors.New(errNotInstanceDisk)
+	}
+
+	m.SetConditions(runtimev1alpha1.Deleting())
+	err := e.client.DeleteInstanceDisk(ctx, m.Status.InstanceID, m.Status.Id)
+	if clients.IsNotFound(err) {
+		return nil
+	}
+	return errors.Wrap(err, errDiskDelete)
+}
+
+// instanceDiskLabel returns the label of the Linode Instance Disk, defaulting
+// to the name of the InstanceDisk.
+func instanceDiskLabel(m *linodev1alpha1.InstanceDisk) string {
+	if m.Spec.Label != "" {
+		return m.Spec.Label
+	}
+	return m.GetName()
+}
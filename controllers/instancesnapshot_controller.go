@@ -0,0 +1,193 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/linode/linodego"
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/meta"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+
+	linodev1alpha1 "github.com/displague/stack-linode/api/v1alpha1"
+	"github.com/displague/stack-linode/clients"
+)
+
+const (
+	errNotInstanceSnapshot = "managed resource is not an InstanceSnapshot"
+	errSnapshotGet         = "cannot get InstanceSnapshot"
+	errSnapshotCreate      = "cannot create InstanceSnapshot"
+)
+
+// InstanceSnapshotController is responsible for adding the InstanceSnapshot
+// controller and its corresponding reconciler to the manager with any runtime configuration.
+type InstanceSnapshotController struct {
+	Options Options
+}
+
+var (
+	instanceSnapshotLog = ctrl.Log.WithName("instancesnapshot.controller")
+)
+
+// SetupWithManager creates a new InstanceSnapshot Controller and adds it to
+// the Manager with default RBAC. The Manager will set fields on the Controller
+// and start it when the Manager is Started.
+func (c *InstanceSnapshotController) SetupWithManager(mgr ctrl.Manager) error {
+	r := resource.NewManagedReconciler(mgr,
+		resource.ManagedKind(linodev1alpha1.InstanceSnapshotGroupVersionKind),
+		resource.WithLongWait(c.Options.pollInterval()),
+		resource.WithExternalConnecter(withTracing(linodev1alpha1.InstanceSnapshotKind, withManagementPolicy(linodev1alpha1.InstanceSnapshotKind, &instanceSnapshotConnecter{client: mgr.GetClient()}))))
+
+	name := strings.ToLower(fmt.Sprintf("%s.%s", linodev1alpha1.InstanceSnapshotKind, linodev1alpha1.Group))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(c.Options.controllerOptions(linodev1alpha1.InstanceSnapshotKind)).
+		For(&linodev1alpha1.InstanceSnapshot{}).
+		Complete(withPause(mgr.GetClient(), &linodev1alpha1.InstanceSnapshot{}, r))
+}
+
+type instanceSnapshotConnecter struct {
+	client      client.Client
+	newClientFn func(credentials []byte) (linodego.Client, error)
+}
+
+// Connect to the supplied resource.Managed (presumed to be an
+// InstanceSnapshot) by using the Provider it references to create a new
+// Linode API client.
+func (c *instanceSnapshotConnecter) Connect(ctx context.Context, mg resource.Managed) (resource.ExternalClient, error) {
+	m, ok := mg.(*linodev1alpha1.InstanceSnapshot)
+	if !ok {
+		return nil, errors.New(errNotInstanceSnapshot)
+	}
+
+	client, err := connectLinode(ctx, c.client, m, c.newClientFn)
+	if err != nil {
+		return nil, err
+	}
+	return &instanceSnapshotExternal{client: client, kube: c.client}, nil
+}
+
+type instanceSnapshotExternal struct {
+	client linodego.Client
+	kube   client.Client
+}
+
+// Observe the existing Linode Instance Snapshot, if any. Snapshots cannot be
+// deleted, so InstanceSnapshots that are being deleted report that their
+// Snapshot does not exist, and are finalized without deleting it.
+func (e *instanceSnapshotExternal) Observe(ctx context.Context, mg resource.Managed) (resource.ExternalObservation, error) {
+	m, ok := mg.(*linodev1alpha1.InstanceSnapshot)
+	if !ok {
+		return resource.ExternalObservation{}, errors.New(errNotInstanceSnapshot)
+	}
+
+	if m.Status.Id == 0 || meta.WasDeleted(m) {
+		return resource.ExternalObservation{}, nil
+	}
+
+	ctx, log := managedLogger(ctx, instanceSnapshotLog, m)
+	snapshot, err := e.client.GetInstanceSnapshot(ctx, m.Status.InstanceID, m.Status.Id)
+	if clients.IsNotFound(err) {
+		return resource.ExternalObservation{}, nil
+	}
+	if err != nil {
+		return resource.ExternalObservation{}, errors.Wrap(err, errSnapshotGet)
+	}
+
+	log.V(1).Info("Observe", "instanceId", m.Status.InstanceID, "snapshotId", m.Status.Id, "status", snapshot.Status)
+
+	m.Status.Status = string(snapshot.Status)
+	m.Status.Label = snapshot.Label
+
+	switch snapshot.Status {
+	case linodego.SnapshotSuccessful:
+		m.Status.SetConditions(runtimev1alpha1.Available())
+		resource.SetBindable(m)
+	case linodego.SnapshotFailed, linodego.SnapshotUserAborted:
+		m.Status.SetConditions(runtimev1alpha1.Unavailable())
+	default:
+		m.Status.SetConditions(runtimev1alpha1.Creating())
+	}
+
+	// Snapshots cannot be changed once they are taken.
+	return resource.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: true,
+	}, nil
+}
+
+// Create a new manual Snapshot of the Linode Instance referenced by the
+// InstanceSnapshot, replacing any previous manual Snapshot of the Instance.
+func (e *instanceSnapshotExternal) Create(ctx context.Context, mg resource.Managed) (resource.ExternalCreation, error) {
+	m, ok := mg.(*linodev1alpha1.InstanceSnapshot)
+	if !ok {
+		return resource.ExternalCreation{}, errors.New(errNotInstanceSnapshot)
+	}
+
+	m.Status.SetConditions(runtimev1alpha1.Creating())
+
+	instanceID, err := getInstanceID(ctx, e.kube, m.GetNamespace(), m.Spec.InstanceRef, m.Spec.InstanceID)
+	if err != nil {
+		return resource.ExternalCreation{}, errors.Wrap(err, errSnapshotCreate)
+	}
+
+	snapshot, err := e.client.CreateInstanceSnapshot(ctx, instanceID, instanceSnapshotLabel(m))
+	if err != nil {
+		return resource.ExternalCreation{}, errors.Wrap(err, errSnapshotCreate)
+	}
+
+	m.Status.Id = snapshot.ID
+	m.Status.InstanceID = instanceID
+
+	return resource.ExternalCreation{}, nil
+}
+
+// Update is a no-op, as Snapshots cannot be changed once they are taken.
+func (e *instanceSnapshotExternal) Update(ctx context.Context, mg resource.Managed) (resource.ExternalUpdate, error) {
+	return resource.ExternalUpdate{}, nil
+}
+
+// Delete the InstanceSnapshot. The Linode API cannot delete Snapshots; they
+// are replaced by the next Snapshot of the Instance, and removed when Backups
+// are cancelled. Delete is not normally called, because Observe reports that
+// the Snapshots of deleted InstanceSnapshots do not exist.
+func (e *instanceSnapshotExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	m, ok := mg.(*linodev1alpha1.InstanceSnapshot)
+	if !ok {
+		return errors.New(errNotInstanceSnapshot)
+	}
+
+	m.SetConditions(runtimev1alpha1.Deleting())
+	return nil
+}
+
+// instanceSnapshotLabel returns the label of the Linode Instance Snapshot,
+// defaulting to the name of the InstanceSnapshot.
+func instanceSnapshotLabel(m *linodev1alpha1.InstanceSnapshot) string {
+	if m.Spec.Label != "" {
+		return m.Spec.Label
+	}
+	return m.GetName()
+}
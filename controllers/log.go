@@ -0,0 +1,41 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel/trace"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/displague/stack-linode/logging"
+)
+
+// managedLogger returns a logger whose messages are correlated with the
+// supplied managed resource by its namespace, name and the supplied values,
+// such as the ID of the Linode resource. The returned context carries the
+// logger, so that Linode API requests made with it are correlated too. Messages
+// are correlated with the trace of the operation when it is traced.
+func managedLogger(ctx context.Context, log logr.Logger, mg metav1.Object, keysAndValues ...interface{}) (context.Context, logr.Logger) {
+	kv := []interface{}{"namespace", mg.GetNamespace(), "name", mg.GetName()}
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		kv = append(kv, "traceId", sc.TraceID().String())
+	}
+	log = log.WithValues(append(kv, keysAndValues...)...)
+	return logging.NewContext(ctx, log), log
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/crossplaneio/crossplane-runtime/pkg/meta"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+
+	linodev1alpha1 "github.com/displague/stack-linode/api/v1alpha1"
+)
+
+const (
+	errObserveOnly            = "external resource does not exist, and is not created under the ObserveOnly management policy"
+	errObserveOnlyUnsupported = "the ObserveOnly management policy is not supported by this kind of managed resource"
+)
+
+// observeOnlyKinds are the kinds of managed resources that can find an
+// existing external resource, and so may only be observed.
+var observeOnlyKinds = map[string]bool{
+	linodev1alpha1.InstanceKind: true,
+	linodev1alpha1.ImageKind:    true,
+	linodev1alpha1.SSHKeyKind:   true,
+	linodev1alpha1.UserKind:     true,
+}
+
+// A managementPolicyGetter is a managed resource with a management policy.
+type managementPolicyGetter interface {
+	GetManagementPolicy() string
+}
+
+// managementPolicy returns the management policy of the supplied managed
+// resource, which is Full by default.
+func managementPolicy(mg resource.Managed) string {
+	if g, ok := mg.(managementPolicyGetter); ok && g.GetManagementPolicy() != "" {
+		return g.GetManagementPolicy()
+	}
+	return linodev1alpha1.ManagementPolicyFull
+}
+
+// withManagementPolicy wraps the ExternalClients connected by the supplied
+// ExternalConnecter for managed resources of the supplied kind, so that they
+// honour the management policy of each managed resource.
+func withManagementPolicy(kind string, c resource.ExternalConnecter) resource.ExternalConnecter {
+	return &managementPolicyConnecter{ExternalConnecter: c, kind: kind}
+}
+
+type managementPolicyConnecter struct {
+	resource.ExternalConnecter
+	kind string
+}
+
+func (c *managementPolicyConnecter) Connect(ctx context.Context, mg resource.Managed) (resource.ExternalClient, error) {
+	e, err := c.ExternalConnecter.Connect(ctx, mg)
+	if err != nil {
+		return nil, err
+	}
+	return &managementPolicyExternal{ExternalClient: e, observeOnly: observeOnlyKinds[c.kind]}, nil
+}
+
+// A managementPolicyExternal skips the operations that the management policy
+// of a managed resource does not allow. Observe is always allowed.
+type managementPolicyExternal struct {
+	resource.ExternalClient
+	observeOnly bool
+}
+
+// Observe the external resource. External resources that are only observed
+// are always up to date, so that they are never updated. Managed resources
+// whose external resources are only observed or may not be deleted are
+// finalized as soon as they are deleted, by reporting that their external
+// resources do not exist.
+func (e *managementPolicyExternal) Observe(ctx context.Context, mg resource.Managed) (resource.ExternalObservation, error) {
+	switch managementPolicy(mg) {
+	case linodev1alpha1.ManagementPolicyObserveOnly:
+		// Even controllers that cannot observe a resource must let it be
+		// deleted.
+		if meta.WasDeleted(mg) {
+			return resource.ExternalObservation{ResourceExists: false}, nil
+		}
+		if !e.observeOnly {
+			return resource.ExternalObservation{}, errors.New(errObserveOnlyUnsupported)
+		}
+	case linodev1alpha1.ManagementPolicyNoDelete:
+		if meta.WasDeleted(mg) {
+			return resource.ExternalObservation{ResourceExists: false}, nil
+		}
+	}
+
+	o, err := e.ExternalClient.Observe(ctx, mg)
+	if err != nil {
+		return o, err
+	}
+	if managementPolicy(mg) == linodev1alpha1.ManagementPolicyObserveOnly {
+		o.ResourceUpToDate = true
+	}
+	return o, nil
+}
+
+// Create the external resource, unless it is only observed. Missing external
+// resources that are only observed are reported as an error.
+func (e *managementPolicyExternal) Create(ctx context.Context, mg resource.Managed) (resource.ExternalCreation, error) {
+	if managementPolicy(mg) == linodev1alpha1.ManagementPolicyObserveOnly {
+		return resource.ExternalCreation{}, errors.New(errObserveOnly)
+	}
+	return e.ExternalClient.Create(ctx, mg)
+}
+
+// Update the external resource, unless it is only observed.
+func (e *managementPolicyExternal) Update(ctx context.Context, mg resource.Managed) (resource.ExternalUpdate, error) {
+	if managementPolicy(mg) == linodev1alpha1.ManagementPolicyObserveOnly {
+		return resource.ExternalUpdate{}, nil
+	}
+	return e.ExternalClient.Update(ctx, mg)
+}
+
+// Delete the external resource, unless it is only observed or may not be
+// deleted. Observe reports that such external resources do not exist once
+// their managed resources are deleted, so they are not normally deleted.
+func (e *managementPolicyExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	switch managementPolicy(mg) {
+	case linodev1alpha1.ManagementPolicyObserveOnly, linodev1alpha1.ManagementPolicyNoDelete:
+		return nil
+	}
+	return e.ExternalClient.Delete(ctx, mg)
+}
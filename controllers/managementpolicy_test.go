@@ -0,0 +1,133 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+
+	linodev1alpha1 "github.com/displague/stack-linode/api/v1alpha1"
+)
+
+func TestManagementPolicyExternal(t *testing.T) {
+	exists := resource.ExternalObservation{ResourceExists: true}
+
+	cases := map[string]struct {
+		policy      string
+		deleted     bool
+		observeOnly bool
+
+		wantObservation resource.ExternalObservation
+		wantObserveErr  string
+		wantObserved    bool
+		wantDeleted     bool
+	}{
+		"Full": {
+			policy:          linodev1alpha1.ManagementPolicyFull,
+			wantObservation: exists,
+			wantObserved:    true,
+			wantDeleted:     true,
+		},
+		"FullDeleted": {
+			policy:          linodev1alpha1.ManagementPolicyFull,
+			deleted:         true,
+			wantObservation: exists,
+			wantObserved:    true,
+			wantDeleted:     true,
+		},
+		"ObserveOnly": {
+			policy:          linodev1alpha1.ManagementPolicyObserveOnly,
+			observeOnly:     true,
+			wantObservation: resource.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			wantObserved:    true,
+		},
+		"ObserveOnlyDeleted": {
+			policy:      linodev1alpha1.ManagementPolicyObserveOnly,
+			deleted:     true,
+			observeOnly: true,
+		},
+		"ObserveOnlyUnsupported": {
+			policy:         linodev1alpha1.ManagementPolicyObserveOnly,
+			wantObserveErr: errObserveOnlyUnsupported,
+		},
+		"ObserveOnlyUnsupportedDeleted": {
+			policy:  linodev1alpha1.ManagementPolicyObserveOnly,
+			deleted: true,
+		},
+		"NoDelete": {
+			policy:          linodev1alpha1.ManagementPolicyNoDelete,
+			wantObservation: exists,
+			wantObserved:    true,
+		},
+		"NoDeleteDeleted": {
+			policy:  linodev1alpha1.ManagementPolicyNoDelete,
+			deleted: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			observed, deleted := false, false
+			e := &managementPolicyExternal{
+				ExternalClient: resource.ExternalClientFns{
+					ObserveFn: func(context.Context, resource.Managed) (resource.ExternalObservation, error) {
+						observed = true
+						return exists, nil
+					},
+					DeleteFn: func(context.Context, resource.Managed) error {
+						deleted = true
+						return nil
+					},
+				},
+				observeOnly: tc.observeOnly,
+			}
+
+			m := &linodev1alpha1.Instance{}
+			m.Spec.ManagementPolicy = tc.policy
+			if tc.deleted {
+				now := metav1.Now()
+				m.SetDeletionTimestamp(&now)
+			}
+
+			o, err := e.Observe(context.Background(), m)
+			if got := fmt.Sprint(err); tc.wantObserveErr != "" && got != tc.wantObserveErr {
+				t.Errorf("Observe(): want error %q, got %q", tc.wantObserveErr, got)
+			} else if tc.wantObserveErr == "" && err != nil {
+				t.Errorf("Observe(): want no error, got %v", err)
+			}
+			if !reflect.DeepEqual(o, tc.wantObservation) {
+				t.Errorf("Observe(): want %+v, got %+v", tc.wantObservation, o)
+			}
+			if observed != tc.wantObserved {
+				t.Errorf("Observe(): want external Observe called %t, got %t", tc.wantObserved, observed)
+			}
+
+			if err := e.Delete(context.Background(), m); err != nil {
+				t.Errorf("Delete(): want no error, got %v", err)
+			}
+			if deleted != tc.wantDeleted {
+				t.Errorf("Delete(): want external Delete called %t, got %t", tc.wantDeleted, deleted)
+			}
+		})
+	}
+}
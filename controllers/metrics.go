@@ -0,0 +1,133 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	linodev1alpha1 "github.com/displague/stack-linode/api/v1alpha1"
+)
+
+// metricsListTimeout bounds the time spent listing managed resources when
+// metrics are scraped.
+const metricsListTimeout = 10 * time.Second
+
+var instanceTimeToReady = prometheus.NewHistogram(prometheus.HistogramOpts{
+	Name:    "linode_instance_time_to_ready_seconds",
+	Help:    "Time from the creation of an Instance until it is first ready.",
+	Buckets: prometheus.ExponentialBuckets(15, 2, 8),
+})
+
+var (
+	managedResourcesDesc = prometheus.NewDesc(
+		"linode_managed_resources",
+		"Number of Linode managed resources by kind.",
+		[]string{"kind"}, nil)
+
+	managedResourceConditionsDesc = prometheus.NewDesc(
+		"linode_managed_resource_conditions",
+		"Number of Linode managed resources by kind, condition type and condition status.",
+		[]string{"kind", "type", "status"}, nil)
+)
+
+// managedResourceLists returns an empty list of each kind of Linode managed
+// resource.
+var managedResourceLists = map[string]func() runtime.Object{
+	linodev1alpha1.InstanceKind:             func() runtime.Object { return &linodev1alpha1.InstanceList{} },
+	linodev1alpha1.InstanceDiskKind:         func() runtime.Object { return &linodev1alpha1.InstanceDiskList{} },
+	linodev1alpha1.InstanceConfigKind:       func() runtime.Object { return &linodev1alpha1.InstanceConfigList{} },
+	linodev1alpha1.InstanceBackupPolicyKind: func() runtime.Object { return &linodev1alpha1.InstanceBackupPolicyList{} },
+	linodev1alpha1.InstanceSnapshotKind:     func() runtime.Object { return &linodev1alpha1.InstanceSnapshotList{} },
+	linodev1alpha1.ImageKind:                func() runtime.Object { return &linodev1alpha1.ImageList{} },
+	linodev1alpha1.SSHKeyKind:               func() runtime.Object { return &linodev1alpha1.SSHKeyList{} },
+	linodev1alpha1.UserKind:                 func() runtime.Object { return &linodev1alpha1.UserList{} },
+	linodev1alpha1.UserGrantsKind:           func() runtime.Object { return &linodev1alpha1.UserGrantsList{} },
+	linodev1alpha1.PersonalAccessTokenKind:  func() runtime.Object { return &linodev1alpha1.PersonalAccessTokenList{} },
+}
+
+func init() {
+	metrics.Registry.MustRegister(instanceTimeToReady)
+}
+
+// A ManagedResourceCollector reports the number of Linode managed resources by
+// kind and condition each time metrics are scraped.
+type ManagedResourceCollector struct {
+	kube client.Reader
+}
+
+// NewManagedResourceCollector returns a ManagedResourceCollector that lists
+// managed resources using the supplied client, which should be backed by the
+// manager's cache.
+func NewManagedResourceCollector(kube client.Reader) *ManagedResourceCollector {
+	return &ManagedResourceCollector{kube: kube}
+}
+
+// Describe the metrics reported by the collector.
+func (c *ManagedResourceCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- managedResourcesDesc
+	ch <- managedResourceConditionsDesc
+}
+
+// Collect the metrics reported by the collector. Kinds of managed resources
+// that cannot be listed are omitted.
+func (c *ManagedResourceCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), metricsListTimeout)
+	defer cancel()
+
+	for kind, newList := range managedResourceLists {
+		l := newList()
+		if err := c.kube.List(ctx, l); err != nil {
+			continue
+		}
+		items, err := meta.ExtractList(l)
+		if err != nil {
+			continue
+		}
+
+		type condition struct{ t, s string }
+		conditions := map[condition]int{}
+		for _, o := range items {
+			u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(o)
+			if err != nil {
+				continue
+			}
+			cs, _, _ := unstructured.NestedSlice(u, "status", "conditions")
+			for _, c := range cs {
+				cm, ok := c.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				t, _, _ := unstructured.NestedString(cm, "type")
+				s, _, _ := unstructured.NestedString(cm, "status")
+				conditions[condition{t, s}]++
+			}
+		}
+
+		ch <- prometheus.MustNewConstMetric(managedResourcesDesc, prometheus.GaugeValue, float64(len(items)), kind)
+		for c, n := range conditions {
+			ch <- prometheus.MustNewConstMetric(managedResourceConditionsDesc, prometheus.GaugeValue, float64(n), kind, c.t, c.s)
+		}
+	}
+}
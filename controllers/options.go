@@ -0,0 +1,83 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	"github.com/displague/stack-linode/catalog"
+)
+
+// Defaults for the runtime configuration of the controllers.
+const (
+	DefaultPollInterval             = 1 * time.Minute
+	DefaultTransitionalPollInterval = 10 * time.Second
+	DefaultMaxConcurrentReconciles  = 1
+)
+
+// Options are the runtime configuration shared by the controllers. The zero
+// value uses the defaults.
+type Options struct {
+	// PollInterval is how often managed resources are observed while their
+	// external resources are stable.
+	PollInterval time.Duration
+
+	// TransitionalPollInterval is how often Instances are observed while
+	// their Linode status is transitional, such as provisioning or booting.
+	TransitionalPollInterval time.Duration
+
+	// MaxConcurrentReconciles is the number of resources of each kind that
+	// may be reconciled concurrently.
+	MaxConcurrentReconciles int
+
+	// MaxConcurrentReconcilesPerKind overrides MaxConcurrentReconciles for
+	// the kinds it contains.
+	MaxConcurrentReconcilesPerKind map[string]int
+
+	// Catalog validates the regions, Instance types and images of managed
+	// resources before they are created. Nothing is validated when it is nil.
+	Catalog *catalog.Catalog
+}
+
+func (o Options) pollInterval() time.Duration {
+	if o.PollInterval <= 0 {
+		return DefaultPollInterval
+	}
+	return o.PollInterval
+}
+
+func (o Options) transitionalPollInterval() time.Duration {
+	if o.TransitionalPollInterval <= 0 {
+		return DefaultTransitionalPollInterval
+	}
+	return o.TransitionalPollInterval
+}
+
+// controllerOptions returns the options of the controller of the supplied
+// kind.
+func (o Options) controllerOptions(kind string) controller.Options {
+	n := o.MaxConcurrentReconciles
+	if k, ok := o.MaxConcurrentReconcilesPerKind[kind]; ok {
+		n = k
+	}
+	if n <= 0 {
+		n = DefaultMaxConcurrentReconciles
+	}
+	return controller.Options{MaxConcurrentReconciles: n}
+}
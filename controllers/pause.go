@@ -0,0 +1,99 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+
+	linodev1alpha1 "github.com/displague/stack-linode/api/v1alpha1"
+)
+
+const (
+	errGetPausable    = "cannot get resource to check whether it is paused"
+	errUpdatePaused   = "cannot update status of paused resource"
+	errUpdateResumed  = "cannot update status of resumed resource"
+	pauseCheckTimeout = 10 * time.Second
+)
+
+// A pausable resource reports whether its reconciliation is paused using its
+// conditions.
+type pausable interface {
+	runtime.Object
+	metav1.Object
+	SetConditions(c ...runtimev1alpha1.Condition)
+	GetCondition(ct runtimev1alpha1.ConditionType) runtimev1alpha1.Condition
+}
+
+// withPause wraps the supplied reconciler of resources of the same kind as
+// the supplied resource, so that resources annotated as paused are not
+// reconciled.
+func withPause(kube client.Client, of pausable, r reconcile.Reconciler) reconcile.Reconciler {
+	return &pausedReconciler{Reconciler: r, kube: kube, of: of}
+}
+
+// A pausedReconciler skips the wrapped reconciler while a resource is paused,
+// reporting that it is paused and not synced. Resources are reconciled again
+// as soon as they are no longer paused, because removing the annotation
+// triggers a reconcile.
+type pausedReconciler struct {
+	reconcile.Reconciler
+	kube client.Client
+	of   pausable
+}
+
+// Reconcile the resource using the wrapped reconciler, unless it is paused.
+func (r *pausedReconciler) Reconcile(req reconcile.Request) (reconcile.Result, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), pauseCheckTimeout)
+	defer cancel()
+
+	o := r.of.DeepCopyObject().(pausable)
+	if err := r.kube.Get(ctx, req.NamespacedName, o); err != nil {
+		if kerrors.IsNotFound(err) {
+			return r.Reconciler.Reconcile(req)
+		}
+		return reconcile.Result{}, errors.Wrap(err, errGetPausable)
+	}
+
+	wasPaused := o.GetCondition(linodev1alpha1.TypePaused).Status == corev1.ConditionTrue
+	switch {
+	case linodev1alpha1.IsPaused(o) && wasPaused:
+		return reconcile.Result{}, nil
+	case linodev1alpha1.IsPaused(o):
+		o.SetConditions(linodev1alpha1.Paused(), linodev1alpha1.ReconcilePaused())
+		return reconcile.Result{}, errors.Wrap(r.kube.Status().Update(ctx, o), errUpdatePaused)
+	case wasPaused:
+		// The wrapped reconciler reports whether the resource is synced
+		// once it has reconciled it.
+		o.SetConditions(linodev1alpha1.Resumed(), runtimev1alpha1.ReconcileSuccess())
+		if err := r.kube.Status().Update(ctx, o); err != nil {
+			return reconcile.Result{}, errors.Wrap(err, errUpdateResumed)
+		}
+	}
+
+	return r.Reconciler.Reconcile(req)
+}
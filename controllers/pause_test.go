@@ -0,0 +1,117 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+
+	linodev1alpha1 "github.com/displague/stack-linode/api/v1alpha1"
+)
+
+func TestPausedReconciler(t *testing.T) {
+	n := types.NamespacedName{Namespace: "default", Name: "test"}
+	instance := func(paused string, conditions ...runtimev1alpha1.Condition) *linodev1alpha1.Instance {
+		m := &linodev1alpha1.Instance{}
+		m.SetNamespace(n.Namespace)
+		m.SetName(n.Name)
+		if paused != "" {
+			m.SetAnnotations(map[string]string{linodev1alpha1.AnnotationPaused: paused})
+		}
+		m.Status.SetConditions(conditions...)
+		return m
+	}
+
+	cases := map[string]struct {
+		existing []runtime.Object
+
+		wantReconciled bool
+		wantPaused     corev1.ConditionStatus
+		wantSynced     corev1.ConditionStatus
+	}{
+		"NotFound": {
+			wantReconciled: true,
+		},
+		"NotPaused": {
+			existing:       []runtime.Object{instance("")},
+			wantReconciled: true,
+			wantPaused:     corev1.ConditionUnknown,
+			wantSynced:     corev1.ConditionUnknown,
+		},
+		"PausedFalse": {
+			existing:       []runtime.Object{instance("false")},
+			wantReconciled: true,
+			wantPaused:     corev1.ConditionUnknown,
+			wantSynced:     corev1.ConditionUnknown,
+		},
+		"Paused": {
+			existing:   []runtime.Object{instance("true")},
+			wantPaused: corev1.ConditionTrue,
+			wantSynced: corev1.ConditionFalse,
+		},
+		"StillPaused": {
+			existing:   []runtime.Object{instance("true", linodev1alpha1.Paused(), linodev1alpha1.ReconcilePaused())},
+			wantPaused: corev1.ConditionTrue,
+			wantSynced: corev1.ConditionFalse,
+		},
+		"Resumed": {
+			existing:       []runtime.Object{instance("", linodev1alpha1.Paused(), linodev1alpha1.ReconcilePaused())},
+			wantReconciled: true,
+			wantPaused:     corev1.ConditionFalse,
+			wantSynced:     corev1.ConditionTrue,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			kube := newFakeKubeClient(t, tc.existing...)
+			reconciled := false
+			r := withPause(kube, &linodev1alpha1.Instance{}, reconcile.Func(func(reconcile.Request) (reconcile.Result, error) {
+				reconciled = true
+				return reconcile.Result{}, nil
+			}))
+
+			if _, err := r.Reconcile(reconcile.Request{NamespacedName: n}); err != nil {
+				t.Fatalf("Reconcile(): %v", err)
+			}
+			if reconciled != tc.wantReconciled {
+				t.Errorf("Reconcile(): want wrapped reconciler called %t, got %t", tc.wantReconciled, reconciled)
+			}
+			if len(tc.existing) == 0 {
+				return
+			}
+
+			m := &linodev1alpha1.Instance{}
+			if err := kube.Get(context.Background(), n, m); err != nil {
+				t.Fatalf("cannot get Instance: %v", err)
+			}
+			if got := m.GetCondition(linodev1alpha1.TypePaused).Status; got != tc.wantPaused {
+				t.Errorf("Reconcile(): want Paused condition %q, got %q", tc.wantPaused, got)
+			}
+			if got := m.GetCondition(runtimev1alpha1.TypeSynced).Status; got != tc.wantSynced {
+				t.Errorf("Reconcile(): want Synced condition %q, got %q", tc.wantSynced, got)
+			}
+		})
+	}
+}
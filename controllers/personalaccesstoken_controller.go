@@ -0,0 +1,374 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/linode/linodego"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+
+	linodev1alpha1 "github.com/displague/stack-linode/api/v1alpha1"
+	"github.com/displague/stack-linode/clients"
+)
+
+const (
+	errNotPersonalAccessToken = "managed resource is not a PersonalAccessToken"
+	errTokenGet               = "cannot get PersonalAccessToken"
+	errTokenCreate            = "cannot create PersonalAccessToken"
+	errTokenUpdate            = "cannot update PersonalAccessToken"
+	errTokenRotate            = "cannot rotate PersonalAccessToken"
+	errTokenDelete            = "cannot delete PersonalAccessToken"
+	errTokenRevokePrevious    = "cannot revoke previous PersonalAccessToken"
+	errTokenSecret            = "cannot get PersonalAccessToken connection secret"
+	errTokenRecord            = "cannot record new PersonalAccessToken in status"
+)
+
+// connectionKeyToken is the key of the token in the connection secret of a
+// PersonalAccessToken.
+const connectionKeyToken = "token"
+
+// tokenExpiryTolerance is how far the observed expiry of a token may be from
+// the expiry requested by its spec without the token being replaced, to allow
+// for the Linode API truncating expiries.
+const tokenExpiryTolerance = time.Minute
+
+// PersonalAccessTokenController is responsible for adding the PersonalAccessToken
+// controller and its corresponding reconciler to the manager with any runtime configuration.
+type PersonalAccessTokenController struct {
+	Options Options
+}
+
+var (
+	personalAccessTokenLog = ctrl.Log.WithName("personalaccesstoken.controller")
+)
+
+// SetupWithManager creates a new PersonalAccessToken Controller and adds it to
+// the Manager with default RBAC. The Manager will set fields on the Controller
+// and start it when the Manager is Started.
+func (c *PersonalAccessTokenController) SetupWithManager(mgr ctrl.Manager) error {
+	r := resource.NewManagedReconciler(mgr,
+		resource.ManagedKind(linodev1alpha1.PersonalAccessTokenGroupVersionKind),
+		resource.WithLongWait(c.Options.pollInterval()),
+		resource.WithExternalConnecter(withTracing(linodev1alpha1.PersonalAccessTokenKind, withManagementPolicy(linodev1alpha1.PersonalAccessTokenKind, &personalAccessTokenConnecter{client: mgr.GetClient()}))))
+
+	name := strings.ToLower(fmt.Sprintf("%s.%s", linodev1alpha1.PersonalAccessTokenKind, linodev1alpha1.Group))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(c.Options.controllerOptions(linodev1alpha1.PersonalAccessTokenKind)).
+		For(&linodev1alpha1.PersonalAccessToken{}).
+		Complete(withPause(mgr.GetClient(), &linodev1alpha1.PersonalAccessToken{}, r))
+}
+
+type personalAccessTokenConnecter struct {
+	client      client.Client
+	newClientFn func(credentials []byte) (linodego.Client, error)
+}
+
+// Connect to the supplied resource.Managed (presumed to be a
+// PersonalAccessToken) by using the Provider it references to create a new
+// Linode API client.
+func (c *personalAccessTokenConnecter) Connect(ctx context.Context, mg resource.Managed) (resource.ExternalClient, error) {
+	m, ok := mg.(*linodev1alpha1.PersonalAccessToken)
+	if !ok {
+		return nil, errors.New(errNotPersonalAccessToken)
+	}
+
+	client, err := connectLinode(ctx, c.client, m, c.newClientFn)
+	if err != nil {
+		return nil, err
+	}
+	return &personalAccessTokenExternal{client: client, kube: c.client}, nil
+}
+
+type personalAccessTokenExternal struct {
+	client linodego.Client
+	kube   client.Client
+
+	// observed is the token got by Observe, which Update reuses.
+	observed *linodego.Token
+}
+
+// Observe the existing Linode Personal Access Token, if any. The token is not
+// up to date once it is due to be rotated, or if it was never published to
+// the connection secret. The tokens it replaced are revoked once it has been
+// published.
+func (e *personalAccessTokenExternal) Observe(ctx context.Context, mg resource.Managed) (resource.ExternalObservation, error) {
+	m, ok := mg.(*linodev1alpha1.PersonalAccessToken)
+	if !ok {
+		return resource.ExternalObservation{}, errors.New(errNotPersonalAccessToken)
+	}
+
+	if m.Status.Id == 0 {
+		return resource.ExternalObservation{}, nil
+	}
+
+	ctx, log := managedLogger(ctx, personalAccessTokenLog, m)
+	token, err := e.client.GetToken(ctx, m.Status.Id)
+	if clients.IsNotFound(err) {
+		return resource.ExternalObservation{}, nil
+	}
+	if err != nil {
+		return resource.ExternalObservation{}, errors.Wrap(err, errTokenGet)
+	}
+
+	e.observed = token
+	published, err := e.tokenPublished(ctx, m, token)
+	if err != nil {
+		return resource.ExternalObservation{}, err
+	}
+
+	log.V(1).Info("Observe", "tokenId", m.Status.Id, "expiry", token.Expiry, "published", published, "previousIds", m.Status.PreviousIds)
+
+	observeToken(m, token)
+	if published {
+		e.revokePrevious(ctx, m)
+	}
+	m.Status.SetConditions(runtimev1alpha1.Available())
+	resource.SetBindable(m)
+
+	return resource.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: token.Label == personalAccessTokenLabel(m) && published && !tokenNeedsRotation(m, time.Now()),
+	}, nil
+}
+
+// Create a new Linode Personal Access Token, publishing it to the connection
+// secret.
+func (e *personalAccessTokenExternal) Create(ctx context.Context, mg resource.Managed) (resource.ExternalCreation, error) {
+	m, ok := mg.(*linodev1alpha1.PersonalAccessToken)
+	if !ok {
+		return resource.ExternalCreation{}, errors.New(errNotPersonalAccessToken)
+	}
+
+	m.Status.SetConditions(runtimev1alpha1.Creating())
+
+	details, err := e.create(ctx, m)
+	return resource.ExternalCreation{ConnectionDetails: details}, errors.Wrap(err, errTokenCreate)
+}
+
+// Update the Linode Personal Access Token. Tokens that are due to be rotated,
+// or that were never published, are replaced by a new token. The replaced
+// token is recorded in the status alongside the new token, and is only
+// revoked once a later Observe finds the new token in the connection secret.
+func (e *personalAccessTokenExternal) Update(ctx context.Context, mg resource.Managed) (resource.ExternalUpdate, error) {
+	m, ok := mg.(*linodev1alpha1.PersonalAccessToken)
+	if !ok {
+		return resource.ExternalUpdate{}, errors.New(errNotPersonalAccessToken)
+	}
+
+	rotate := tokenNeedsRotation(m, time.Now())
+	if !rotate {
+		token := e.observed
+		if token == nil || token.ID != m.Status.Id {
+			var err error
+			if token, err = e.client.GetToken(ctx, m.Status.Id); err != nil {
+				return resource.ExternalUpdate{}, errors.Wrap(err, errTokenGet)
+			}
+		}
+		published, err := e.tokenPublished(ctx, m, token)
+		if err != nil {
+			return resource.ExternalUpdate{}, err
+		}
+		rotate = !published
+	}
+
+	if !rotate {
+		opts := linodego.TokenUpdateOptions{Label: personalAccessTokenLabel(m)}
+		_, err := e.client.UpdateToken(ctx, m.Status.Id, opts)
+		return resource.ExternalUpdate{}, errors.Wrap(err, errTokenUpdate)
+	}
+
+	details, err := e.create(ctx, m)
+	if err != nil {
+		return resource.ExternalUpdate{}, errors.Wrap(err, errTokenRotate)
+	}
+	return resource.ExternalUpdate{ConnectionDetails: details}, nil
+}
+
+// Delete the Linode Personal Access Token, revoking it.
+func (e *personalAccessTokenExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	m, ok := mg.(*linodev1alpha1.PersonalAccessToken)
+	if !ok {
+		return errors.New(errNotPersonalAccessToken)
+	}
+
+	m.SetConditions(runtimev1alpha1.Deleting())
+	e.revokePrevious(ctx, m)
+	err := e.client.DeleteToken(ctx, m.Status.Id)
+	if clients.IsNotFound(err) {
+		return nil
+	}
+	return errors.Wrap(err, errTokenDelete)
+}
+
+// tokenPublished returns true if the connection secret of the supplied
+// PersonalAccessToken holds the supplied token. The Linode API only returns
+// the first characters of existing tokens, which identify them. Tokens of
+// PersonalAccessTokens without a connection secret are never published, so
+// they are considered published.
+func (e *personalAccessTokenExternal) tokenPublished(ctx context.Context, m *linodev1alpha1.PersonalAccessToken, token *linodego.Token) (bool, error) {
+	ref := m.GetWriteConnectionSecretToReference()
+	if ref.Name == "" {
+		return true, nil
+	}
+
+	s := &corev1.Secret{}
+	err := e.kube.Get(ctx, types.NamespacedName{Namespace: m.GetNamespace(), Name: ref.Name}, s)
+	if kerrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Wrap(err, errTokenSecret)
+	}
+	return token.Token != "" && strings.HasPrefix(string(s.Data[connectionKeyToken]), token.Token), nil
+}
+
+// revokePrevious revokes the tokens replaced by the current token of the
+// supplied PersonalAccessToken. Tokens that cannot be revoked remain in its
+// status, and are revoked the next time it is observed.
+func (e *personalAccessTokenExternal) revokePrevious(ctx context.Context, m *linodev1alpha1.PersonalAccessToken) {
+	remaining := []int{}
+	for _, id := range m.Status.PreviousIds {
+		if err := e.client.DeleteToken(ctx, id); err != nil && !clients.IsNotFound(err) {
+			personalAccessTokenLog.Error(err, errTokenRevokePrevious, "namespace", m.GetNamespace(), "name", m.GetName(), "tokenId", id)
+			remaining = append(remaining, id)
+		}
+	}
+	m.Status.PreviousIds = nil
+	if len(remaining) > 0 {
+		m.Status.PreviousIds = remaining
+	}
+}
+
+// create a new Linode Personal Access Token, replacing the current token of
+// the supplied PersonalAccessToken, if any. The full token is only returned
+// when it is created, so it is recorded in the status before it is published:
+// tokens that are recorded but never published are replaced and revoked in
+// turn, while tokens that cannot be recorded are revoked immediately.
+func (e *personalAccessTokenExternal) create(ctx context.Context, m *linodev1alpha1.PersonalAccessToken) (resource.ConnectionDetails, error) {
+	opts := linodego.TokenCreateOptions{
+		Label:  personalAccessTokenLabel(m),
+		Scopes: m.Spec.Scopes,
+	}
+	if m.Spec.ExpiresIn != nil {
+		expiry := time.Now().Add(m.Spec.ExpiresIn.Duration)
+		opts.Expiry = &expiry
+	}
+
+	token, err := e.client.CreateToken(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	status := m.Status.DeepCopy()
+	if m.Status.Id != 0 {
+		m.Status.PreviousIds = append(m.Status.PreviousIds, m.Status.Id)
+	}
+	m.Status.Id = token.ID
+	observeToken(m, token)
+	if err := e.kube.Status().Update(ctx, m); err != nil {
+		if err := e.client.DeleteToken(ctx, token.ID); err != nil {
+			personalAccessTokenLog.Error(err, errTokenDelete, "namespace", m.GetNamespace(), "name", m.GetName(), "tokenId", token.ID)
+		}
+		m.Status = *status
+		return nil, errors.Wrap(err, errTokenRecord)
+	}
+
+	return resource.ConnectionDetails{connectionKeyToken: []byte(token.Token)}, nil
+}
+
+// observeToken records the observed state of a Linode Personal Access Token.
+func observeToken(m *linodev1alpha1.PersonalAccessToken, token *linodego.Token) {
+	m.Status.Label = token.Label
+	m.Status.Scopes = token.Scopes
+	m.Status.Created = nil
+	m.Status.Expiry = nil
+	if token.Created != nil {
+		m.Status.Created = &metav1.Time{Time: *token.Created}
+	}
+	if token.Expiry != nil {
+		m.Status.Expiry = &metav1.Time{Time: *token.Expiry}
+	}
+}
+
+// tokenNeedsRotation returns true if the observed token is due to expire, or
+// no longer has the scopes or lifetime requested by the spec.
+func tokenNeedsRotation(m *linodev1alpha1.PersonalAccessToken, now time.Time) bool {
+	if !sameScopes(m.Spec.Scopes, m.Status.Scopes) {
+		return true
+	}
+
+	expiresIn, expiry := m.Spec.ExpiresIn, m.Status.Expiry
+	switch {
+	case expiresIn == nil:
+		return expiry != nil
+	case expiry == nil || m.Status.Created == nil:
+		return true
+	}
+
+	lifetime := expiry.Sub(m.Status.Created.Time)
+	if d := lifetime - expiresIn.Duration; d > tokenExpiryTolerance || d < -tokenExpiryTolerance {
+		return true
+	}
+
+	rotateBefore := expiresIn.Duration / 5
+	if m.Spec.RotateBefore != nil {
+		rotateBefore = m.Spec.RotateBefore.Duration
+	}
+	return !now.Before(expiry.Add(-rotateBefore))
+}
+
+// sameScopes returns true if the supplied space separated OAuth scopes are
+// the same, regardless of their order.
+func sameScopes(a, b string) bool {
+	as, bs := strings.Fields(a), strings.Fields(b)
+	if len(as) != len(bs) {
+		return false
+	}
+	sort.Strings(as)
+	sort.Strings(bs)
+	for i := range as {
+		if as[i] != bs[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// personalAccessTokenLabel returns the label of the Linode Personal Access
+// Token, defaulting to the name of the PersonalAccessToken.
+func personalAccessTokenLabel(m *linodev1alpha1.PersonalAccessToken) string {
+	if m.Spec.Label != "" {
+		return m.Spec.Label
+	}
+	return m.GetName()
+}
@@ -0,0 +1,352 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/linode/linodego"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+
+	linodev1alpha1 "github.com/displague/stack-linode/api/v1alpha1"
+)
+
+func TestTokenNeedsRotation(t *testing.T) {
+	now := time.Date(2019, 10, 1, 0, 0, 0, 0, time.UTC)
+	created := &metav1.Time{Time: now.Add(-24 * time.Hour)}
+	expiry := func(d time.Duration) *metav1.Time { return &metav1.Time{Time: created.Add(d)} }
+	duration := func(d time.Duration) *metav1.Duration { return &metav1.Duration{Duration: d} }
+
+	cases := map[string]struct {
+		spec   linodev1alpha1.PersonalAccessTokenParameters
+		status linodev1alpha1.PersonalAccessTokenStatus
+		want   bool
+	}{
+		"NeverExpires": {
+			spec:   linodev1alpha1.PersonalAccessTokenParameters{Scopes: "*"},
+			status: linodev1alpha1.PersonalAccessTokenStatus{Scopes: "*", Created: created},
+			want:   false,
+		},
+		"ScopesReordered": {
+			spec:   linodev1alpha1.PersonalAccessTokenParameters{Scopes: "linodes:read_write domains:read_only"},
+			status: linodev1alpha1.PersonalAccessTokenStatus{Scopes: "domains:read_only linodes:read_write", Created: created},
+			want:   false,
+		},
+		"ScopesChanged": {
+			spec:   linodev1alpha1.PersonalAccessTokenParameters{Scopes: "linodes:read_write"},
+			status: linodev1alpha1.PersonalAccessTokenStatus{Scopes: "linodes:read_only", Created: created},
+			want:   true,
+		},
+		"ExpiryRemoved": {
+			spec:   linodev1alpha1.PersonalAccessTokenParameters{Scopes: "*"},
+			status: linodev1alpha1.PersonalAccessTokenStatus{Scopes: "*", Created: created, Expiry: expiry(720 * time.Hour)},
+			want:   true,
+		},
+		"ExpiryAdded": {
+			spec:   linodev1alpha1.PersonalAccessTokenParameters{Scopes: "*", ExpiresIn: duration(720 * time.Hour)},
+			status: linodev1alpha1.PersonalAccessTokenStatus{Scopes: "*", Created: created},
+			want:   true,
+		},
+		"LifetimeWithinTolerance": {
+			spec:   linodev1alpha1.PersonalAccessTokenParameters{Scopes: "*", ExpiresIn: duration(720 * time.Hour)},
+			status: linodev1alpha1.PersonalAccessTokenStatus{Scopes: "*", Created: created, Expiry: expiry(720*time.Hour - 30*time.Second)},
+			want:   false,
+		},
+		"LifetimeChanged": {
+			spec:   linodev1alpha1.PersonalAccessTokenParameters{Scopes: "*", ExpiresIn: duration(720 * time.Hour)},
+			status: linodev1alpha1.PersonalAccessTokenStatus{Scopes: "*", Created: created, Expiry: expiry(360 * time.Hour)},
+			want:   true,
+		},
+		"DefaultRotateBeforeNotDue": {
+			spec:   linodev1alpha1.PersonalAccessTokenParameters{Scopes: "*", ExpiresIn: duration(120 * time.Hour)},
+			status: linodev1alpha1.PersonalAccessTokenStatus{Scopes: "*", Created: created, Expiry: expiry(120 * time.Hour)},
+			want:   false,
+		},
+		"DefaultRotateBeforeDue": {
+			spec:   linodev1alpha1.PersonalAccessTokenParameters{Scopes: "*", ExpiresIn: duration(25 * time.Hour)},
+			status: linodev1alpha1.PersonalAccessTokenStatus{Scopes: "*", Created: created, Expiry: expiry(25 * time.Hour)},
+			want:   true,
+		},
+		"RotateBeforeDue": {
+			spec:   linodev1alpha1.PersonalAccessTokenParameters{Scopes: "*", ExpiresIn: duration(48 * time.Hour), RotateBefore: duration(24 * time.Hour)},
+			status: linodev1alpha1.PersonalAccessTokenStatus{Scopes: "*", Created: created, Expiry: expiry(48 * time.Hour)},
+			want:   true,
+		},
+		"RotateBeforeNotDue": {
+			spec:   linodev1alpha1.PersonalAccessTokenParameters{Scopes: "*", ExpiresIn: duration(48 * time.Hour), RotateBefore: duration(12 * time.Hour)},
+			status: linodev1alpha1.PersonalAccessTokenStatus{Scopes: "*", Created: created, Expiry: expiry(48 * time.Hour)},
+			want:   false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			m := &linodev1alpha1.PersonalAccessToken{}
+			m.Spec.PersonalAccessTokenParameters = tc.spec
+			m.Status = tc.status
+			if got := tokenNeedsRotation(m, now); got != tc.want {
+				t.Errorf("tokenNeedsRotation(...): want %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
+
+// A fakeTokenAPI serves the Personal Access Token endpoints of the Linode API.
+// Like the Linode API, it only returns the first 16 characters of existing
+// tokens.
+type fakeTokenAPI struct {
+	mu     sync.Mutex
+	next   int
+	gets   int
+	tokens map[int]*linodego.Token
+}
+
+// newToken returns a new token, whose first characters are unique to it.
+func (a *fakeTokenAPI) newToken(created time.Time, expiry *time.Time) *linodego.Token {
+	a.next++
+	t := &linodego.Token{ID: a.next, Label: "token", Scopes: "*", Token: strings.Repeat(strconv.Itoa(a.next), 64)[:64], Created: &created, Expiry: expiry}
+	a.tokens[t.ID] = t
+	return t
+}
+
+func (a *fakeTokenAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+
+	encode := func(t *linodego.Token, full bool) {
+		body := map[string]interface{}{"id": t.ID, "label": t.Label, "scopes": t.Scopes, "token": t.Token, "created": t.Created.Format(fakeDateLayout)}
+		if !full {
+			body["token"] = t.Token[:16]
+		}
+		if t.Expiry != nil {
+			body["expiry"] = t.Expiry.Format(fakeDateLayout)
+		}
+		_ = json.NewEncoder(w).Encode(body)
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/profile/tokens")
+	if path == "" && r.Method == http.MethodPost {
+		opts := struct {
+			Expiry *string `json:"expiry"`
+		}{}
+		_ = json.NewDecoder(r.Body).Decode(&opts)
+		var expiry *time.Time
+		if opts.Expiry != nil {
+			if t, err := time.Parse(fakeDateLayout, *opts.Expiry); err == nil {
+				expiry = &t
+			}
+		}
+		encode(a.newToken(time.Now().UTC().Truncate(time.Second), expiry), true)
+		return
+	}
+
+	id, err := strconv.Atoi(strings.TrimPrefix(path, "/"))
+	t, ok := a.tokens[id]
+	if err != nil || !ok {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"errors": [{"reason": "Not found"}]}`))
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		a.gets++
+		encode(t, false)
+	case http.MethodPut:
+		encode(t, false)
+	case http.MethodDelete:
+		delete(a.tokens, id)
+		_, _ = w.Write([]byte(`{}`))
+	}
+}
+
+func (a *fakeTokenAPI) exists(id int) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	_, ok := a.tokens[id]
+	return ok
+}
+
+// fakeDateLayout is the layout of dates returned by the Linode API.
+const fakeDateLayout = "2006-01-02T15:04:05"
+
+// newFakeKubeClient returns a fake Kubernetes client that knows about the
+// supplied objects, including Linode managed resources.
+func newFakeKubeClient(t *testing.T, objs ...runtime.Object) client.Client {
+	s := runtime.NewScheme()
+	if err := corev1.AddToScheme(s); err != nil {
+		t.Fatalf("corev1.AddToScheme(...): %v", err)
+	}
+	if err := linodev1alpha1.AddToScheme(s); err != nil {
+		t.Fatalf("linodev1alpha1.AddToScheme(...): %v", err)
+	}
+	return fake.NewFakeClientWithScheme(s, objs...)
+}
+
+func TestPersonalAccessTokenRotation(t *testing.T) {
+	ctx := context.Background()
+	// The first token expires within the default rotation window of a fifth
+	// of its 30h lifetime.
+	api := &fakeTokenAPI{tokens: map[int]*linodego.Token{}}
+	created := time.Now().Add(-24 * time.Hour).UTC().Truncate(time.Second)
+	expiry := created.Add(30 * time.Hour)
+	first := api.newToken(created, &expiry)
+
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+
+	lc := linodego.NewClient(srv.Client())
+	lc.SetBaseURL(srv.URL)
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "token"},
+		Data:       map[string][]byte{connectionKeyToken: []byte(first.Token)},
+	}
+	m := &linodev1alpha1.PersonalAccessToken{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "token"}}
+	m.Spec.Label = "token"
+	m.Spec.Scopes = "*"
+	m.Spec.ExpiresIn = &metav1.Duration{Duration: 30 * time.Hour}
+	m.Spec.WriteConnectionSecretToReference = corev1.LocalObjectReference{Name: "token"}
+	m.Status.Id = first.ID
+
+	kube := newFakeKubeClient(t, secret, m)
+	e := &personalAccessTokenExternal{client: lc, kube: kube}
+
+	observe := func(wantUpToDate bool) {
+		t.Helper()
+		o, err := e.Observe(ctx, m)
+		if err != nil {
+			t.Fatalf("Observe(...): %v", err)
+		}
+		if !o.ResourceExists || o.ResourceUpToDate != wantUpToDate {
+			t.Fatalf("Observe(...): want exists and up to date %t, got %+v", wantUpToDate, o)
+		}
+	}
+	update := func() string {
+		t.Helper()
+		u, err := e.Update(ctx, m)
+		if err != nil {
+			t.Fatalf("Update(...): %v", err)
+		}
+		return string(u.ConnectionDetails[connectionKeyToken])
+	}
+	publish := func(token string) {
+		t.Helper()
+		secret.Data[connectionKeyToken] = []byte(token)
+		if err := kube.Update(ctx, secret); err != nil {
+			t.Fatalf("cannot publish token: %v", err)
+		}
+	}
+
+	// The token is due to be rotated. Rotating it does not revoke it.
+	observe(false)
+	second := update()
+	if m.Status.Id != 2 || !reflect.DeepEqual(m.Status.PreviousIds, []int{1}) {
+		t.Fatalf("after rotation: want Id 2 and PreviousIds [1], got %d and %v", m.Status.Id, m.Status.PreviousIds)
+	}
+	if !api.exists(1) {
+		t.Fatalf("token 1 was revoked before its replacement was published")
+	}
+
+	// The second token was never published, so it is replaced too, and the
+	// first token remains valid.
+	m.Status.SetConditions(runtimev1alpha1.ReconcileError(fmt.Errorf("cannot publish %s", second[:4])))
+	m.Spec.ExpiresIn = &metav1.Duration{Duration: 720 * time.Hour}
+	observe(false)
+	third := update()
+	if m.Status.Id != 3 || !reflect.DeepEqual(m.Status.PreviousIds, []int{1, 2}) {
+		t.Fatalf("after second rotation: want Id 3 and PreviousIds [1 2], got %d and %v", m.Status.Id, m.Status.PreviousIds)
+	}
+	if !api.exists(1) || !api.exists(2) {
+		t.Fatalf("previous tokens were revoked before their replacement was published")
+	}
+
+	// Once the third token is published, the tokens it replaced are revoked.
+	publish(third)
+	observe(true)
+	if len(m.Status.PreviousIds) != 0 {
+		t.Errorf("after publishing: want no PreviousIds, got %v", m.Status.PreviousIds)
+	}
+	if api.exists(1) || api.exists(2) || !api.exists(3) {
+		t.Errorf("after publishing: want only token 3, got %v", api.tokens)
+	}
+
+	// The new tokens were recorded in the status before they were published.
+	stored := &linodev1alpha1.PersonalAccessToken{}
+	if err := kube.Get(ctx, types.NamespacedName{Namespace: "default", Name: "token"}, stored); err != nil {
+		t.Fatalf("cannot get PersonalAccessToken: %v", err)
+	}
+	if stored.Status.Id != 3 || !reflect.DeepEqual(stored.Status.PreviousIds, []int{1, 2}) {
+		t.Errorf("stored status: want Id 3 and PreviousIds [1 2], got %d and %v", stored.Status.Id, stored.Status.PreviousIds)
+	}
+
+	// Updates reuse the token got by Observe.
+	api.gets = 0
+	m.Spec.Label = "renamed"
+	observe(false)
+	update()
+	if api.gets != 1 {
+		t.Errorf("Observe and Update: want 1 GetToken request, got %d", api.gets)
+	}
+}
+
+func TestPersonalAccessTokenRotationNotRecorded(t *testing.T) {
+	ctx := context.Background()
+	api := &fakeTokenAPI{tokens: map[int]*linodego.Token{}}
+	first := api.newToken(time.Now().UTC().Truncate(time.Second), nil)
+
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+
+	lc := linodego.NewClient(srv.Client())
+	lc.SetBaseURL(srv.URL)
+
+	// The PersonalAccessToken does not exist, so its status cannot be
+	// updated.
+	e := &personalAccessTokenExternal{client: lc, kube: newFakeKubeClient(t)}
+	m := &linodev1alpha1.PersonalAccessToken{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "token"}}
+	m.Spec.Scopes = "linodes:read_only"
+	m.Status.Id = first.ID
+	m.Status.Scopes = "*"
+
+	if _, err := e.Update(ctx, m); err == nil {
+		t.Fatalf("Update(...): want error recording the new token, got none")
+	}
+	if m.Status.Id != first.ID || len(m.Status.PreviousIds) != 0 {
+		t.Errorf("Update(...): want status unchanged, got Id %d and PreviousIds %v", m.Status.Id, m.Status.PreviousIds)
+	}
+	if !api.exists(first.ID) || len(api.tokens) != 1 {
+		t.Errorf("Update(...): want only the unrecorded token revoked, got %v", api.tokens)
+	}
+}
@@ -0,0 +1,297 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/linode/linodego"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/crossplaneio/crossplane-runtime/pkg/meta"
+
+	linodev1alpha1 "github.com/displague/stack-linode/api/v1alpha1"
+	"github.com/displague/stack-linode/clients"
+)
+
+const (
+	errProviderGet          = "cannot get Provider"
+	errProviderUpdateStatus = "cannot update Provider status"
+	errProviderListForToken = "cannot list Providers referencing Secret"
+	errProviderIndexSecret  = "cannot index Providers by credentials Secret"
+)
+
+// providerSecretField indexes Providers by the name of the Secret holding
+// their credentials, if any.
+const providerSecretField = "spec.credentialsSecretRef.name"
+
+// Reasons for the events emitted by the Provider controller.
+const (
+	reasonCannotGetCredentials = "CannotGetCredentials"
+	reasonInvalidCredentials   = "InvalidCredentials"
+	reasonInsufficientScopes   = "InsufficientScopes"
+	reasonTokenExpiring        = "TokenExpiring"
+)
+
+const (
+	// providerValidationInterval is how often Provider credentials are
+	// validated in the absence of changes to the Provider or its Secret.
+	providerValidationInterval = 10 * time.Minute
+
+	// providerTokenExpiryWarning is how long before they expire that events
+	// warn that Provider credentials are expiring.
+	providerTokenExpiryWarning = 7 * 24 * time.Hour
+)
+
+// providerRequiredScopes are the OAuth scopes required to manage each kind of
+// Linode managed resource.
+var providerRequiredScopes = map[string][]string{
+	linodev1alpha1.InstanceKind:             {"linodes:read_write", "events:read_only"},
+	linodev1alpha1.InstanceDiskKind:         {"linodes:read_write"},
+	linodev1alpha1.InstanceConfigKind:       {"linodes:read_write"},
+	linodev1alpha1.InstanceBackupPolicyKind: {"linodes:read_write"},
+	linodev1alpha1.InstanceSnapshotKind:     {"linodes:read_write"},
+	linodev1alpha1.ImageKind:                {"images:read_write", "linodes:read_only"},
+	linodev1alpha1.SSHKeyKind:               {"account:read_write"},
+	linodev1alpha1.UserKind:                 {"account:read_write"},
+	linodev1alpha1.UserGrantsKind:           {"account:read_write"},
+	linodev1alpha1.PersonalAccessTokenKind:  {"*"},
+}
+
+// ProviderController is responsible for adding the Provider controller and
+// its corresponding reconciler to the manager with any runtime configuration.
+type ProviderController struct {
+	Options Options
+}
+
+var (
+	providerLog = ctrl.Log.WithName("provider.controller")
+)
+
+// SetupWithManager creates a new Provider Controller and adds it to the
+// Manager with default RBAC. The Manager will set fields on the Controller and
+// start it when the Manager is Started.
+func (c *ProviderController) SetupWithManager(mgr ctrl.Manager) error {
+	name := strings.ToLower(fmt.Sprintf("%s.%s", linodev1alpha1.ProviderKind, linodev1alpha1.Group))
+
+	r := &providerReconciler{
+		kube:     mgr.GetClient(),
+		recorder: mgr.GetEventRecorderFor(name),
+	}
+
+	w := newCredentialsFileWatcher(mgr.GetClient())
+	if err := mgr.Add(w); err != nil {
+		return err
+	}
+
+	if err := mgr.GetFieldIndexer().IndexField(&linodev1alpha1.Provider{}, providerSecretField, providerSecretName); err != nil {
+		return errors.Wrap(err, errProviderIndexSecret)
+	}
+
+	// Only changes to Secrets referenced by a Provider are reconciled.
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(c.Options.controllerOptions(linodev1alpha1.ProviderKind)).
+		For(&linodev1alpha1.Provider{}).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, &handler.EnqueueRequestsFromMapFunc{
+			ToRequests: handler.ToRequestsFunc(r.providersForSecret),
+		}).
+		WithEventFilter(predicate.Funcs{
+			CreateFunc:  func(e event.CreateEvent) bool { return r.referenced(e.Meta, e.Object) },
+			UpdateFunc:  func(e event.UpdateEvent) bool { return r.referenced(e.MetaNew, e.ObjectNew) },
+			DeleteFunc:  func(e event.DeleteEvent) bool { return r.referenced(e.Meta, e.Object) },
+			GenericFunc: func(e event.GenericEvent) bool { return r.referenced(e.Meta, e.Object) },
+		}).
+		Watches(&source.Channel{Source: w.events}, &handler.EnqueueRequestForObject{}).
+		Complete(withPause(mgr.GetClient(), &linodev1alpha1.Provider{}, r))
+}
+
+// A providerReconciler validates the credentials of a Provider and reports
+// them in its status.
+type providerReconciler struct {
+	kube        client.Client
+	recorder    record.EventRecorder
+	newClientFn func(credentials []byte) (linodego.Client, error)
+}
+
+// Reconcile validates the credentials of the Provider: the token must be
+// present in its credentials source, it must be accepted by the Linode API, and
+// it should have the OAuth scopes required by each kind of managed resource.
+func (r *providerReconciler) Reconcile(req reconcile.Request) (reconcile.Result, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	p := &linodev1alpha1.Provider{}
+	if err := r.kube.Get(ctx, req.NamespacedName, p); err != nil {
+		if kerrors.IsNotFound(err) {
+			providerClients.RemoveOwner(clientOwner{kind: linodev1alpha1.ProviderKind, name: req.NamespacedName})
+		}
+		return reconcile.Result{}, errors.Wrap(client.IgnoreNotFound(err), errProviderGet)
+	}
+
+	if meta.WasDeleted(p) {
+		providerClients.Remove(p.GetUID())
+		return reconcile.Result{}, nil
+	}
+
+	r.validate(ctx, p)
+
+	return reconcile.Result{RequeueAfter: providerValidationInterval}, errors.Wrap(r.kube.Status().Update(ctx, p), errProviderUpdateStatus)
+}
+
+// validate the credentials of the Provider, recording the result in its
+// status and emitting events for any failures.
+func (r *providerReconciler) validate(ctx context.Context, p *linodev1alpha1.Provider) {
+	credentials, version, err := getCredentials(ctx, r.kube, p)
+	if err != nil {
+		providerClients.Remove(p.GetUID())
+		msg := err.Error()
+		p.Status.SetConditions(linodev1alpha1.CredentialsMissing(msg))
+		r.recorder.Event(p, corev1.EventTypeWarning, reasonCannotGetCredentials, msg)
+		return
+	}
+
+	token := strings.TrimSpace(string(credentials))
+	if token == "" {
+		msg := fmt.Sprintf("provider credentials from %s source are empty", p.CredentialsSource())
+		p.Status.SetConditions(linodev1alpha1.CredentialsMissing(msg))
+		r.recorder.Event(p, corev1.EventTypeWarning, reasonCannotGetCredentials, msg)
+		return
+	}
+
+	var lc linodego.Client
+	if r.newClientFn != nil {
+		lc, err = r.newClientFn([]byte(token))
+	} else {
+		lc, err = providerClients.Get(p.GetUID(), clientOwner{kind: linodev1alpha1.ProviderKind, name: types.NamespacedName{Namespace: p.GetNamespace(), Name: p.GetName()}}, version, credentials)
+	}
+	if err != nil {
+		msg := fmt.Sprintf("cannot create Linode client: %s", err)
+		p.Status.SetConditions(linodev1alpha1.CredentialsInvalid(msg))
+		r.recorder.Event(p, corev1.EventTypeWarning, reasonInvalidCredentials, msg)
+		return
+	}
+
+	profile, scopes, err := clients.GetProfileScopes(ctx, &lc)
+	if err != nil {
+		msg := fmt.Sprintf("cannot get Linode profile: %s", err)
+		p.Status.SetConditions(linodev1alpha1.CredentialsInvalid(msg))
+		r.recorder.Event(p, corev1.EventTypeWarning, reasonInvalidCredentials, msg)
+		return
+	}
+
+	p.Status.Username = profile.Username
+	p.Status.Email = profile.Email
+	p.Status.UserID = profile.UID
+	p.Status.Scopes = scopes
+	p.Status.SetConditions(linodev1alpha1.CredentialsValid())
+
+	if msg := missingScopesMessage(scopes); msg != "" {
+		p.Status.SetConditions(linodev1alpha1.ScopesInsufficient(msg))
+		r.recorder.Event(p, corev1.EventTypeWarning, reasonInsufficientScopes, msg)
+	} else {
+		p.Status.SetConditions(linodev1alpha1.ScopesSufficient())
+	}
+
+	// Only tokens with sufficient scopes may list Personal Access Tokens, so
+	// the expiry of other tokens is unknown.
+	p.Status.TokenExpiry = nil
+	t, err := clients.FindToken(ctx, &lc, token)
+	if err != nil {
+		providerLog.V(1).Info("cannot determine token expiry", "provider", p.GetName(), "error", err.Error())
+		return
+	}
+	if t != nil && t.Expiry != nil {
+		p.Status.TokenExpiry = &metav1.Time{Time: *t.Expiry}
+		if time.Until(*t.Expiry) < providerTokenExpiryWarning {
+			r.recorder.Eventf(p, corev1.EventTypeWarning, reasonTokenExpiring, "provider token expires at %s", t.Expiry.UTC().Format(time.RFC3339))
+		}
+	}
+}
+
+// providerSecretName returns the name of the Secret holding the credentials of
+// the supplied Provider, if any, for indexing.
+func providerSecretName(o runtime.Object) []string {
+	p, ok := o.(*linodev1alpha1.Provider)
+	if !ok || p.CredentialsSource() != linodev1alpha1.CredentialsSourceSecret || p.Spec.Secret.Name == "" {
+		return nil
+	}
+	return []string{p.Spec.Secret.Name}
+}
+
+// referenced returns false for Secrets that are not referenced by a Provider,
+// and true for every other object.
+func (r *providerReconciler) referenced(m metav1.Object, o runtime.Object) bool {
+	if _, ok := o.(*corev1.Secret); !ok {
+		return true
+	}
+	return len(r.providersForSecret(handler.MapObject{Meta: m, Object: o})) > 0
+}
+
+// providersForSecret returns a request for each Provider that references the
+// supplied Secret.
+func (r *providerReconciler) providersForSecret(o handler.MapObject) []reconcile.Request {
+	l := &linodev1alpha1.ProviderList{}
+	if err := r.kube.List(context.Background(), l, client.InNamespace(o.Meta.GetNamespace()), client.MatchingField(providerSecretField, o.Meta.GetName())); err != nil {
+		if !kerrors.IsNotFound(err) {
+			providerLog.Error(err, errProviderListForToken, "namespace", o.Meta.GetNamespace(), "secret", o.Meta.GetName())
+		}
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(l.Items))
+	for _, p := range l.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: p.GetNamespace(), Name: p.GetName()}})
+	}
+	return requests
+}
+
+// missingScopesMessage describes the kinds of managed resources that cannot
+// be managed with the supplied OAuth scopes, or returns an empty string if
+// every kind can be managed.
+func missingScopesMessage(scopes string) string {
+	kinds := make([]string, 0, len(providerRequiredScopes))
+	for kind := range providerRequiredScopes {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+
+	var msgs []string
+	for _, kind := range kinds {
+		if missing := clients.MissingScopes(scopes, providerRequiredScopes[kind]); len(missing) > 0 {
+			msgs = append(msgs, fmt.Sprintf("%s requires %s", kind, strings.Join(missing, ", ")))
+		}
+	}
+	return strings.Join(msgs, "; ")
+}
@@ -0,0 +1,257 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/crossplaneio/crossplane-runtime/pkg/meta"
+
+	linodev1alpha1 "github.com/displague/stack-linode/api/v1alpha1"
+)
+
+const (
+	errProviderConfigGet          = "cannot get ProviderConfig"
+	errProviderConfigUpdate       = "cannot update ProviderConfig"
+	errProviderConfigUpdateStatus = "cannot update ProviderConfig status"
+	errProviderConfigListUsages   = "cannot list ProviderConfigUsages"
+	errProviderConfigDeleteUsage  = "cannot delete stale ProviderConfigUsage"
+	errProviderConfigGetUser      = "cannot get managed resource using ProviderConfig"
+	errProviderConfigGetUsage     = "cannot get ProviderConfigUsage"
+	errProviderConfigCreateUsage  = "cannot create ProviderConfigUsage"
+	errProviderConfigUpdateUsage  = "cannot update ProviderConfigUsage"
+)
+
+// reasonProviderConfigInUse is the reason of the event emitted when a
+// ProviderConfig cannot be deleted because it is in use.
+const reasonProviderConfigInUse = "InUse"
+
+// finalizerProviderConfigInUse blocks the deletion of ProviderConfigs that are
+// used by managed resources.
+const finalizerProviderConfigInUse = "in-use." + linodev1alpha1.Group
+
+const (
+	// providerConfigUsageInterval is how often stale ProviderConfigUsages are
+	// removed in the absence of changes.
+	providerConfigUsageInterval = 10 * time.Minute
+
+	// providerConfigDeletionInterval is how often usages are checked again
+	// while a ProviderConfig that is in use is being deleted.
+	providerConfigDeletionInterval = 30 * time.Second
+)
+
+// ProviderConfigController is responsible for adding the ProviderConfig
+// controller and its corresponding reconciler to the manager with any runtime
+// configuration.
+type ProviderConfigController struct {
+	Options Options
+}
+
+var (
+	providerConfigLog = ctrl.Log.WithName("providerconfig.controller")
+)
+
+// SetupWithManager creates a new ProviderConfig Controller and adds it to the
+// Manager with default RBAC. The Manager will set fields on the Controller and
+// start it when the Manager is Started.
+func (c *ProviderConfigController) SetupWithManager(mgr ctrl.Manager) error {
+	name := strings.ToLower(fmt.Sprintf("%s.%s", linodev1alpha1.ProviderConfigKind, linodev1alpha1.Group))
+
+	r := &providerConfigReconciler{
+		kube:     mgr.GetClient(),
+		recorder: mgr.GetEventRecorderFor(name),
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(c.Options.controllerOptions(linodev1alpha1.ProviderConfigKind)).
+		For(&linodev1alpha1.ProviderConfig{}).
+		Watches(&source.Kind{Type: &linodev1alpha1.ProviderConfigUsage{}}, &handler.EnqueueRequestsFromMapFunc{
+			ToRequests: handler.ToRequestsFunc(providerConfigForUsage),
+		}).
+		Complete(withPause(mgr.GetClient(), &linodev1alpha1.ProviderConfig{}, r))
+}
+
+// A providerConfigReconciler counts the managed resources using a
+// ProviderConfig, and blocks its deletion while any remain.
+type providerConfigReconciler struct {
+	kube     client.Client
+	recorder record.EventRecorder
+}
+
+// Reconcile removes stale ProviderConfigUsages of the ProviderConfig, reports
+// the number of managed resources using it, and removes its finalizer once it
+// is deleted and no longer in use.
+func (r *providerConfigReconciler) Reconcile(req reconcile.Request) (reconcile.Result, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	pc := &linodev1alpha1.ProviderConfig{}
+	if err := r.kube.Get(ctx, req.NamespacedName, pc); err != nil {
+		if kerrors.IsNotFound(err) {
+			providerClients.RemoveOwner(clientOwner{kind: linodev1alpha1.ProviderConfigKind, name: req.NamespacedName})
+		}
+		return reconcile.Result{}, errors.Wrap(client.IgnoreNotFound(err), errProviderConfigGet)
+	}
+
+	users, err := r.countUsers(ctx, pc)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	pc.Status.Users = users
+	if err := r.kube.Status().Update(ctx, pc); err != nil {
+		return reconcile.Result{}, errors.Wrap(err, errProviderConfigUpdateStatus)
+	}
+
+	if meta.WasDeleted(pc) {
+		if users > 0 {
+			r.recorder.Eventf(pc, corev1.EventTypeWarning, reasonProviderConfigInUse, "cannot delete ProviderConfig: in use by %d managed resources", users)
+			return reconcile.Result{RequeueAfter: providerConfigDeletionInterval}, nil
+		}
+		providerClients.Remove(pc.GetUID())
+		meta.RemoveFinalizer(pc, finalizerProviderConfigInUse)
+		return reconcile.Result{}, errors.Wrap(r.kube.Update(ctx, pc), errProviderConfigUpdate)
+	}
+
+	if hasFinalizer(pc, finalizerProviderConfigInUse) {
+		return reconcile.Result{RequeueAfter: providerConfigUsageInterval}, nil
+	}
+	meta.AddFinalizer(pc, finalizerProviderConfigInUse)
+	return reconcile.Result{RequeueAfter: providerConfigUsageInterval}, errors.Wrap(r.kube.Update(ctx, pc), errProviderConfigUpdate)
+}
+
+func hasFinalizer(o metav1.Object, finalizer string) bool {
+	for _, f := range o.GetFinalizers() {
+		if f == finalizer {
+			return true
+		}
+	}
+	return false
+}
+
+// countUsers returns the number of managed resources using the supplied
+// ProviderConfig, deleting the ProviderConfigUsages of managed resources that
+// no longer exist or no longer reference it.
+func (r *providerConfigReconciler) countUsers(ctx context.Context, pc *linodev1alpha1.ProviderConfig) (int64, error) {
+	l := &linodev1alpha1.ProviderConfigUsageList{}
+	if err := r.kube.List(ctx, l, client.MatchingLabels{linodev1alpha1.LabelProviderConfigName: pc.GetName()}); err != nil {
+		return 0, errors.Wrap(err, errProviderConfigListUsages)
+	}
+
+	var users int64
+	for i := range l.Items {
+		u := &l.Items[i]
+		used, err := r.inUse(ctx, pc, u)
+		if err != nil {
+			return 0, err
+		}
+		if used {
+			users++
+			continue
+		}
+		if err := r.kube.Delete(ctx, u); client.IgnoreNotFound(err) != nil {
+			return 0, errors.Wrap(err, errProviderConfigDeleteUsage)
+		}
+	}
+	return users, nil
+}
+
+// inUse returns true if the managed resource recorded by the supplied
+// ProviderConfigUsage still exists and references the ProviderConfig.
+func (r *providerConfigReconciler) inUse(ctx context.Context, pc *linodev1alpha1.ProviderConfig, u *linodev1alpha1.ProviderConfigUsage) (bool, error) {
+	if u.ProviderConfigReference.Name != pc.GetName() {
+		return false, nil
+	}
+
+	ref := u.ResourceReference
+	mg := &unstructured.Unstructured{}
+	mg.SetAPIVersion(ref.APIVersion)
+	mg.SetKind(ref.Kind)
+	if err := r.kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, mg); err != nil {
+		if kerrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, errors.Wrapf(err, "%s %s %s/%s", errProviderConfigGetUser, ref.Kind, ref.Namespace, ref.Name)
+	}
+	if mg.GetUID() != ref.UID {
+		return false, nil
+	}
+
+	kind, _, _ := unstructured.NestedString(mg.Object, "spec", "providerRef", "kind")
+	name, _, _ := unstructured.NestedString(mg.Object, "spec", "providerRef", "name")
+	return kind == linodev1alpha1.ProviderConfigKind && name == pc.GetName(), nil
+}
+
+// providerConfigForUsage returns a request for the ProviderConfig recorded by
+// the supplied ProviderConfigUsage.
+func providerConfigForUsage(o handler.MapObject) []reconcile.Request {
+	name := o.Meta.GetLabels()[linodev1alpha1.LabelProviderConfigName]
+	if name == "" {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: name}}}
+}
+
+// trackProviderConfigUsage records that the supplied managed resource uses the
+// supplied ProviderConfig, creating or updating its ProviderConfigUsage.
+func trackProviderConfigUsage(ctx context.Context, kube client.Client, pc *linodev1alpha1.ProviderConfig, mg providerReferencer) error {
+	u := &linodev1alpha1.ProviderConfigUsage{}
+	n := types.NamespacedName{Name: string(mg.GetUID())}
+	err := kube.Get(ctx, n, u)
+	if err != nil && !kerrors.IsNotFound(err) {
+		return errors.Wrap(err, errProviderConfigGetUsage)
+	}
+
+	if kerrors.IsNotFound(err) {
+		u = &linodev1alpha1.ProviderConfigUsage{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   n.Name,
+				Labels: map[string]string{linodev1alpha1.LabelProviderConfigName: pc.GetName()},
+			},
+			ProviderConfigReference: corev1.LocalObjectReference{Name: pc.GetName()},
+			ResourceReference:       *meta.ReferenceTo(mg, mg.GetObjectKind().GroupVersionKind()),
+		}
+		if err := kube.Create(ctx, u); err != nil && !kerrors.IsAlreadyExists(err) {
+			return errors.Wrap(err, errProviderConfigCreateUsage)
+		}
+		return nil
+	}
+
+	if u.ProviderConfigReference.Name == pc.GetName() {
+		return nil
+	}
+	u.ProviderConfigReference.Name = pc.GetName()
+	meta.AddLabels(u, map[string]string{linodev1alpha1.LabelProviderConfigName: pc.GetName()})
+	return errors.Wrap(kube.Update(ctx, u), errProviderConfigUpdateUsage)
+}
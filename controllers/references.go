@@ -0,0 +1,192 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/linode/linodego"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	linodev1alpha1 "github.com/displague/stack-linode/api/v1alpha1"
+	"github.com/displague/stack-linode/clients"
+)
+
+const (
+	errGetReferencedInstance = "cannot get referenced Instance"
+	errGetReferencedDisk     = "cannot get referenced InstanceDisk"
+	errInstanceNotCreated    = "referenced Instance has not been created"
+	errDiskNotCreated        = "referenced InstanceDisk has not been created"
+	errNoInstance            = "neither an Instance reference nor an Instance ID is set"
+	errGetReferencedImage    = "cannot get referenced Image"
+	errImageNotAvailable     = "referenced Image is not available"
+	errGetReferencedSnapshot = "cannot get referenced InstanceSnapshot"
+	errSnapshotNotAvailable  = "referenced InstanceSnapshot is not available"
+	errGetReferencedSSHKey   = "cannot get referenced SSHKey"
+	errGetReferencedUser     = "cannot get referenced User"
+	errUserNotCreated        = "referenced User has not been created"
+	errNoUser                = "neither a User reference nor a Username is set"
+)
+
+// getInstanceID returns the Linode ID of the Instance referenced in the
+// supplied namespace, or the supplied ID if there is no reference.
+func getInstanceID(ctx context.Context, kube client.Client, namespace string, ref *corev1.LocalObjectReference, id int) (int, error) {
+	if ref == nil {
+		if id == 0 {
+			return 0, errors.New(errNoInstance)
+		}
+		return id, nil
+	}
+
+	i := &linodev1alpha1.Instance{}
+	if err := kube.Get(ctx, types.NamespacedName{Namespace: namespace, Name: ref.Name}, i); err != nil {
+		return 0, errors.Wrap(err, errGetReferencedInstance)
+	}
+	if i.Status.Id == 0 {
+		return 0, errors.New(errInstanceNotCreated)
+	}
+	return i.Status.Id, nil
+}
+
+// getDevice converts an InstanceDevice to the device expected by the Linode
+// API, resolving any InstanceDisk it references in the supplied namespace.
+func getDevice(ctx context.Context, kube client.Client, namespace string, d *linodev1alpha1.InstanceDevice) (*linodego.InstanceConfigDevice, error) {
+	if d == nil {
+		return nil, nil
+	}
+	if d.DiskRef == nil {
+		return &linodego.InstanceConfigDevice{DiskID: d.DiskID, VolumeID: d.VolumeID}, nil
+	}
+
+	disk := &linodev1alpha1.InstanceDisk{}
+	if err := kube.Get(ctx, types.NamespacedName{Namespace: namespace, Name: d.DiskRef.Name}, disk); err != nil {
+		return nil, errors.Wrap(err, errGetReferencedDisk)
+	}
+	if disk.Status.Id == 0 {
+		return nil, errors.New(errDiskNotCreated)
+	}
+	return &linodego.InstanceConfigDevice{DiskID: disk.Status.Id}, nil
+}
+
+// getDeviceMap converts an InstanceDeviceMap to the device map expected by
+// the Linode API, resolving any InstanceDisks it references in the supplied
+// namespace.
+func getDeviceMap(ctx context.Context, kube client.Client, namespace string, d *linodev1alpha1.InstanceDeviceMap) (linodego.InstanceConfigDeviceMap, error) {
+	devices := linodego.InstanceConfigDeviceMap{}
+	if d == nil {
+		return devices, nil
+	}
+
+	slots := []struct {
+		in  *linodev1alpha1.InstanceDevice
+		out **linodego.InstanceConfigDevice
+	}{
+		{d.SDA, &devices.SDA},
+		{d.SDB, &devices.SDB},
+		{d.SDC, &devices.SDC},
+		{d.SDD, &devices.SDD},
+		{d.SDE, &devices.SDE},
+		{d.SDF, &devices.SDF},
+		{d.SDG, &devices.SDG},
+		{d.SDH, &devices.SDH},
+	}
+	for _, s := range slots {
+		device, err := getDevice(ctx, kube, namespace, s.in)
+		if err != nil {
+			return devices, err
+		}
+		*s.out = device
+	}
+	return devices, nil
+}
+
+// getImageID returns the Linode ID of the Image referenced in the supplied
+// namespace, or the supplied ID if there is no reference. Referenced Images
+// must be available before they can be deployed.
+func getImageID(ctx context.Context, kube client.Client, namespace string, ref *corev1.LocalObjectReference, id string) (string, error) {
+	if ref == nil {
+		return id, nil
+	}
+
+	i := &linodev1alpha1.Image{}
+	if err := kube.Get(ctx, types.NamespacedName{Namespace: namespace, Name: ref.Name}, i); err != nil {
+		return "", errors.Wrap(err, errGetReferencedImage)
+	}
+	if i.Status.Id == "" || i.Status.Status != clients.ImageAvailable {
+		return "", errors.New(errImageNotAvailable)
+	}
+	return i.Status.Id, nil
+}
+
+// getBackupID returns the Linode ID of the Backup taken by the InstanceSnapshot
+// referenced in the supplied namespace, or the supplied ID if there is no
+// reference.
+func getBackupID(ctx context.Context, kube client.Client, namespace string, ref *corev1.LocalObjectReference, id int) (int, error) {
+	if ref == nil {
+		return id, nil
+	}
+
+	s := &linodev1alpha1.InstanceSnapshot{}
+	if err := kube.Get(ctx, types.NamespacedName{Namespace: namespace, Name: ref.Name}, s); err != nil {
+		return 0, errors.Wrap(err, errGetReferencedSnapshot)
+	}
+	if s.Status.Id == 0 || s.Status.Status != string(linodego.SnapshotSuccessful) {
+		return 0, errors.New(errSnapshotNotAvailable)
+	}
+	return s.Status.Id, nil
+}
+
+// getAuthorizedKeys returns the public keys of the SSHKeys referenced in the
+// supplied namespace.
+func getAuthorizedKeys(ctx context.Context, kube client.Client, namespace string, refs []corev1.LocalObjectReference) ([]string, error) {
+	keys := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		k := &linodev1alpha1.SSHKey{}
+		if err := kube.Get(ctx, types.NamespacedName{Namespace: namespace, Name: ref.Name}, k); err != nil {
+			return nil, errors.Wrap(err, errGetReferencedSSHKey)
+		}
+		publicKey, err := sshKeyPublicKey(ctx, kube, k)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, publicKey)
+	}
+	return keys, nil
+}
+
+// getUsername returns the name of the Linode User referenced in the supplied
+// namespace, or the supplied username if there is no reference.
+func getUsername(ctx context.Context, kube client.Client, namespace string, ref *corev1.LocalObjectReference, username string) (string, error) {
+	if ref == nil {
+		if username == "" {
+			return "", errors.New(errNoUser)
+		}
+		return username, nil
+	}
+
+	u := &linodev1alpha1.User{}
+	if err := kube.Get(ctx, types.NamespacedName{Namespace: namespace, Name: ref.Name}, u); err != nil {
+		return "", errors.Wrap(err, errGetReferencedUser)
+	}
+	if u.Status.Username == "" {
+		return "", errors.New(errUserNotCreated)
+	}
+	return u.Status.Username, nil
+}
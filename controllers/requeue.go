@@ -0,0 +1,76 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/linode/linodego"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	linodev1alpha1 "github.com/displague/stack-linode/api/v1alpha1"
+)
+
+// isTransitionalStatus lists the Linode statuses an Instance reports while it
+// is changing from one stable status to another.
+var isTransitionalStatus = map[string]bool{
+	string(linodego.InstanceProvisioning): true,
+	string(linodego.InstanceBooting):      true,
+	string(linodego.InstanceRebooting):    true,
+	string(linodego.InstanceShuttingDown): true,
+	string(linodego.InstanceMigrating):    true,
+	string(linodego.InstanceRebuilding):   true,
+	string(linodego.InstanceCloning):      true,
+	string(linodego.InstanceRestoring):    true,
+	string(linodego.InstanceResizing):     true,
+	string(linodego.InstanceDeleting):     true,
+}
+
+// An instanceRequeuer requeues Instances after a short interval while their
+// Linode status is transitional, such as provisioning or booting, and after
+// the poll interval of the wrapped reconciler once they are stable.
+type instanceRequeuer struct {
+	reconcile.Reconciler
+	kube     client.Reader
+	interval time.Duration
+}
+
+// Reconcile the Instance using the wrapped reconciler, then shorten the
+// requeue interval if the Instance is in transition.
+func (r *instanceRequeuer) Reconcile(req reconcile.Request) (reconcile.Result, error) {
+	result, err := r.Reconciler.Reconcile(req)
+	if err != nil || result.RequeueAfter <= r.interval {
+		return result, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	m := &linodev1alpha1.Instance{}
+	if err := r.kube.Get(ctx, req.NamespacedName, m); err != nil {
+		return result, nil
+	}
+
+	// Instances that were just created have not been observed yet.
+	created := m.Status.Id != 0 && m.Status.Status == ""
+	if created || isTransitionalStatus[m.Status.Status] {
+		result.RequeueAfter = r.interval
+	}
+	return result, nil
+}
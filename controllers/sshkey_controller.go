@@ -0,0 +1,268 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/linode/linodego"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+
+	linodev1alpha1 "github.com/displague/stack-linode/api/v1alpha1"
+	"github.com/displague/stack-linode/clients"
+)
+
+const (
+	errNotSSHKey          = "managed resource is not an SSHKey"
+	errSSHKeyGet          = "cannot get SSHKey"
+	errSSHKeyFind         = "cannot find SSHKey by label"
+	errSSHKeyCreate       = "cannot create SSHKey"
+	errSSHKeyUpdate       = "cannot update SSHKey"
+	errSSHKeyDelete       = "cannot delete SSHKey"
+	errSSHKeySecret       = "cannot get SSHKey public key Secret"
+	errSSHKeyNoPublicKey  = "SSHKey has no public key"
+	errSSHKeySecretNoData = "SSHKey public key Secret has no data for key"
+)
+
+// SSHKeyController is responsible for adding the SSHKey
+// controller and its corresponding reconciler to the manager with any runtime configuration.
+type SSHKeyController struct {
+	Options Options
+}
+
+var (
+	sshKeyLog = ctrl.Log.WithName("sshkey.controller")
+)
+
+// SetupWithManager creates a new SSHKey Controller and adds it to the
+// Manager with default RBAC. The Manager will set fields on the Controller and
+// start it when the Manager is Started.
+func (c *SSHKeyController) SetupWithManager(mgr ctrl.Manager) error {
+	r := resource.NewManagedReconciler(mgr,
+		resource.ManagedKind(linodev1alpha1.SSHKeyGroupVersionKind),
+		resource.WithLongWait(c.Options.pollInterval()),
+		resource.WithExternalConnecter(withTracing(linodev1alpha1.SSHKeyKind, withManagementPolicy(linodev1alpha1.SSHKeyKind, &sshKeyConnecter{client: mgr.GetClient()}))))
+
+	name := strings.ToLower(fmt.Sprintf("%s.%s", linodev1alpha1.SSHKeyKind, linodev1alpha1.Group))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(c.Options.controllerOptions(linodev1alpha1.SSHKeyKind)).
+		For(&linodev1alpha1.SSHKey{}).
+		Complete(withPause(mgr.GetClient(), &linodev1alpha1.SSHKey{}, r))
+}
+
+type sshKeyConnecter struct {
+	client      client.Client
+	newClientFn func(credentials []byte) (linodego.Client, error)
+}
+
+// Connect to the supplied resource.Managed (presumed to be an SSHKey) by
+// using the Provider it references to create a new Linode API client.
+func (c *sshKeyConnecter) Connect(ctx context.Context, mg resource.Managed) (resource.ExternalClient, error) {
+	m, ok := mg.(*linodev1alpha1.SSHKey)
+	if !ok {
+		return nil, errors.New(errNotSSHKey)
+	}
+
+	client, err := connectLinode(ctx, c.client, m, c.newClientFn)
+	if err != nil {
+		return nil, err
+	}
+	return &sshKeyExternal{client: client, kube: c.client}, nil
+}
+
+type sshKeyExternal struct {
+	client linodego.Client
+	kube   client.Client
+}
+
+// Observe the existing Linode SSH Key, if any.
+func (e *sshKeyExternal) Observe(ctx context.Context, mg resource.Managed) (resource.ExternalObservation, error) {
+	m, ok := mg.(*linodev1alpha1.SSHKey)
+	if !ok {
+		return resource.ExternalObservation{}, errors.New(errNotSSHKey)
+	}
+
+	// SSHKeys that are only observed are never created, so the existing
+	// Linode SSH Key is found by its label.
+	if m.Status.Id == 0 && managementPolicy(m) == linodev1alpha1.ManagementPolicyObserveOnly {
+		id, err := e.findSSHKey(ctx, sshKeyLabel(m))
+		if err != nil {
+			return resource.ExternalObservation{}, err
+		}
+		m.Status.Id = id
+	}
+
+	if m.Status.Id == 0 {
+		return resource.ExternalObservation{}, nil
+	}
+
+	ctx, log := managedLogger(ctx, sshKeyLog, m)
+	key, err := e.client.GetSSHKey(ctx, m.Status.Id)
+	if clients.IsNotFound(err) {
+		return resource.ExternalObservation{}, nil
+	}
+	if err != nil {
+		return resource.ExternalObservation{}, errors.Wrap(err, errSSHKeyGet)
+	}
+
+	publicKey, err := sshKeyPublicKey(ctx, e.kube, m)
+	if err != nil {
+		return resource.ExternalObservation{}, err
+	}
+
+	log.V(1).Info("Observe", "sshKeyId", m.Status.Id, "label", key.Label)
+
+	m.Status.Label = key.Label
+	m.Status.PublicKey = key.SSHKey
+	m.Status.SetConditions(runtimev1alpha1.Available())
+	resource.SetBindable(m)
+
+	return resource.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: key.Label == sshKeyLabel(m) && strings.TrimSpace(key.SSHKey) == publicKey,
+	}, nil
+}
+
+// Create a new Linode SSH Key in the profile of the Provider's Linode user.
+func (e *sshKeyExternal) Create(ctx context.Context, mg resource.Managed) (resource.ExternalCreation, error) {
+	m, ok := mg.(*linodev1alpha1.SSHKey)
+	if !ok {
+		return resource.ExternalCreation{}, errors.New(errNotSSHKey)
+	}
+
+	m.Status.SetConditions(runtimev1alpha1.Creating())
+
+	return resource.ExternalCreation{}, errors.Wrap(e.create(ctx, m), errSSHKeyCreate)
+}
+
+// Update the Linode SSH Key to match the SSHKey. The public key of a Linode
+// SSH Key cannot be changed, so a changed public key replaces the Linode SSH
+// Key.
+func (e *sshKeyExternal) Update(ctx context.Context, mg resource.Managed) (resource.ExternalUpdate, error) {
+	m, ok := mg.(*linodev1alpha1.SSHKey)
+	if !ok {
+		return resource.ExternalUpdate{}, errors.New(errNotSSHKey)
+	}
+
+	publicKey, err := sshKeyPublicKey(ctx, e.kube, m)
+	if err != nil {
+		return resource.ExternalUpdate{}, err
+	}
+
+	if strings.TrimSpace(m.Status.PublicKey) != publicKey {
+		if err := e.client.DeleteSSHKey(ctx, m.Status.Id); err != nil && !clients.IsNotFound(err) {
+			return resource.ExternalUpdate{}, errors.Wrap(err, errSSHKeyDelete)
+		}
+		return resource.ExternalUpdate{}, errors.Wrap(e.create(ctx, m), errSSHKeyCreate)
+	}
+
+	opts := linodego.SSHKeyUpdateOptions{Label: sshKeyLabel(m)}
+	if _, err := e.client.UpdateSSHKey(ctx, m.Status.Id, opts); err != nil {
+		return resource.ExternalUpdate{}, errors.Wrap(err, errSSHKeyUpdate)
+	}
+
+	return resource.ExternalUpdate{}, nil
+}
+
+// Delete the Linode SSH Key.
+func (e *sshKeyExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	m, ok := mg.(*linodev1alpha1.SSHKey)
+	if !ok {
+		return errors.New(errNotSSHKey)
+	}
+
+	m.SetConditions(runtimev1alpha1.Deleting())
+	err := e.client.DeleteSSHKey(ctx, m.Status.Id)
+	if clients.IsNotFound(err) {
+		return nil
+	}
+	return errors.Wrap(err, errSSHKeyDelete)
+}
+
+func (e *sshKeyExternal) create(ctx context.Context, m *linodev1alpha1.SSHKey) error {
+	publicKey, err := sshKeyPublicKey(ctx, e.kube, m)
+	if err != nil {
+		return err
+	}
+
+	key, err := e.client.CreateSSHKey(ctx, linodego.SSHKeyCreateOptions{
+		Label:  sshKeyLabel(m),
+		SSHKey: publicKey,
+	})
+	if err != nil {
+		return err
+	}
+
+	m.Status.Id = key.ID
+	m.Status.PublicKey = key.SSHKey
+	return nil
+}
+
+// sshKeyPublicKey returns the public key of the SSHKey, reading it from the
+// referenced Secret when it is not set inline.
+func sshKeyPublicKey(ctx context.Context, kube client.Client, m *linodev1alpha1.SSHKey) (string, error) {
+	ref := m.Spec.PublicKeySecretRef
+	if ref == nil {
+		if m.Spec.PublicKey == "" {
+			return "", errors.New(errSSHKeyNoPublicKey)
+		}
+		return strings.TrimSpace(m.Spec.PublicKey), nil
+	}
+
+	s := &corev1.Secret{}
+	if err := kube.Get(ctx, types.NamespacedName{Namespace: m.GetNamespace(), Name: ref.Name}, s); err != nil {
+		return "", errors.Wrap(err, errSSHKeySecret)
+	}
+	publicKey, ok := s.Data[ref.Key]
+	if !ok {
+		return "", errors.Errorf("%s %s", errSSHKeySecretNoData, ref.Key)
+	}
+	return strings.TrimSpace(string(publicKey)), nil
+}
+
+// sshKeyLabel returns the label of the Linode SSH Key, defaulting to the name
+// of the SSHKey.
+// findSSHKey returns the ID of the Linode SSH Key with the supplied label, or
+// zero if there is none.
+func (e *sshKeyExternal) findSSHKey(ctx context.Context, label string) (int, error) {
+	keys, err := e.client.ListSSHKeys(ctx, linodego.NewListOptions(0, fmt.Sprintf(`{"label": %q}`, label)))
+	if err != nil {
+		return 0, errors.Wrap(err, errSSHKeyFind)
+	}
+	if len(keys) == 0 {
+		return 0, nil
+	}
+	return keys[0].ID, nil
+}
+
+func sshKeyLabel(m *linodev1alpha1.SSHKey) string {
+	if m.Spec.Label != "" {
+		return m.Spec.Label
+	}
+	return m.GetName()
+}
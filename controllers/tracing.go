@@ -0,0 +1,105 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+
+	"github.com/displague/stack-linode/tracing"
+)
+
+// withTracing wraps the ExternalClients connected by the supplied
+// ExternalConnecter for managed resources of the supplied kind, so that each
+// operation is recorded as a span. The managed reconciler does not pass the
+// context of a reconcile to its ExternalClient, so each operation starts a
+// trace of its own. The context passed to each operation carries its span,
+// so that the Linode API requests it makes are recorded as its children.
+func withTracing(kind string, c resource.ExternalConnecter) resource.ExternalConnecter {
+	return &tracingConnecter{ExternalConnecter: c, kind: kind}
+}
+
+type tracingConnecter struct {
+	resource.ExternalConnecter
+	kind string
+}
+
+func (c *tracingConnecter) Connect(ctx context.Context, mg resource.Managed) (resource.ExternalClient, error) {
+	e, err := c.ExternalConnecter.Connect(ctx, mg)
+	if err != nil {
+		return nil, err
+	}
+	return &tracingExternal{ExternalClient: e, kind: c.kind}, nil
+}
+
+// A tracingExternal records each operation of an ExternalClient as a span.
+type tracingExternal struct {
+	resource.ExternalClient
+	kind string
+}
+
+func (e *tracingExternal) start(ctx context.Context, op string, mg resource.Managed) (context.Context, trace.Span) {
+	return otel.Tracer(tracing.InstrumentationName).Start(ctx, e.kind+" "+op,
+		trace.WithAttributes(
+			attribute.String("kind", e.kind),
+			attribute.String("namespace", mg.GetNamespace()),
+			attribute.String("name", mg.GetName())))
+}
+
+// end records the supplied error, if any, and ends the supplied span.
+func end(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+func (e *tracingExternal) Observe(ctx context.Context, mg resource.Managed) (resource.ExternalObservation, error) {
+	ctx, span := e.start(ctx, "Observe", mg)
+	o, err := e.ExternalClient.Observe(ctx, mg)
+	span.SetAttributes(attribute.Bool("resourceExists", o.ResourceExists), attribute.Bool("resourceUpToDate", o.ResourceUpToDate))
+	end(span, err)
+	return o, err
+}
+
+func (e *tracingExternal) Create(ctx context.Context, mg resource.Managed) (resource.ExternalCreation, error) {
+	ctx, span := e.start(ctx, "Create", mg)
+	c, err := e.ExternalClient.Create(ctx, mg)
+	end(span, err)
+	return c, err
+}
+
+func (e *tracingExternal) Update(ctx context.Context, mg resource.Managed) (resource.ExternalUpdate, error) {
+	ctx, span := e.start(ctx, "Update", mg)
+	u, err := e.ExternalClient.Update(ctx, mg)
+	end(span, err)
+	return u, err
+}
+
+func (e *tracingExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	ctx, span := e.start(ctx, "Delete", mg)
+	err := e.ExternalClient.Delete(ctx, mg)
+	end(span, err)
+	return err
+}
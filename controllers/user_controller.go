@@ -0,0 +1,198 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/linode/linodego"
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+
+	linodev1alpha1 "github.com/displague/stack-linode/api/v1alpha1"
+	"github.com/displague/stack-linode/clients"
+)
+
+const (
+	errNotUser    = "managed resource is not a User"
+	errUserGet    = "cannot get User"
+	errUserCreate = "cannot create User"
+	errUserUpdate = "cannot update User"
+	errUserDelete = "cannot delete User"
+)
+
+// UserController is responsible for adding the User
+// controller and its corresponding reconciler to the manager with any runtime configuration.
+type UserController struct {
+	Options Options
+}
+
+var (
+	userLog = ctrl.Log.WithName("user.controller")
+)
+
+// SetupWithManager creates a new User Controller and adds it to the
+// Manager with default RBAC. The Manager will set fields on the Controller and
+// start it when the Manager is Started.
+func (c *UserController) SetupWithManager(mgr ctrl.Manager) error {
+	r := resource.NewManagedReconciler(mgr,
+		resource.ManagedKind(linodev1alpha1.UserGroupVersionKind),
+		resource.WithLongWait(c.Options.pollInterval()),
+		resource.WithExternalConnecter(withTracing(linodev1alpha1.UserKind, withManagementPolicy(linodev1alpha1.UserKind, &userConnecter{client: mgr.GetClient()}))))
+
+	name := strings.ToLower(fmt.Sprintf("%s.%s", linodev1alpha1.UserKind, linodev1alpha1.Group))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(c.Options.controllerOptions(linodev1alpha1.UserKind)).
+		For(&linodev1alpha1.User{}).
+		Complete(withPause(mgr.GetClient(), &linodev1alpha1.User{}, r))
+}
+
+type userConnecter struct {
+	client      client.Client
+	newClientFn func(credentials []byte) (linodego.Client, error)
+}
+
+// Connect to the supplied resource.Managed (presumed to be a User) by using
+// the Provider it references to create a new Linode API client.
+func (c *userConnecter) Connect(ctx context.Context, mg resource.Managed) (resource.ExternalClient, error) {
+	m, ok := mg.(*linodev1alpha1.User)
+	if !ok {
+		return nil, errors.New(errNotUser)
+	}
+
+	client, err := connectLinode(ctx, c.client, m, c.newClientFn)
+	if err != nil {
+		return nil, err
+	}
+	return &userExternal{client: client}, nil
+}
+
+type userExternal struct {
+	client linodego.Client
+}
+
+// Observe the existing Linode User, if any.
+func (e *userExternal) Observe(ctx context.Context, mg resource.Managed) (resource.ExternalObservation, error) {
+	m, ok := mg.(*linodev1alpha1.User)
+	if !ok {
+		return resource.ExternalObservation{}, errors.New(errNotUser)
+	}
+
+	// Users that are only observed are never created, so the existing Linode
+	// User is found by its username.
+	if m.Status.Username == "" && managementPolicy(m) == linodev1alpha1.ManagementPolicyObserveOnly {
+		m.Status.Username = m.Spec.Username
+	}
+
+	if m.Status.Username == "" {
+		return resource.ExternalObservation{}, nil
+	}
+
+	ctx, log := managedLogger(ctx, userLog, m)
+	user, err := e.client.GetUser(ctx, m.Status.Username)
+	if clients.IsNotFound(err) {
+		return resource.ExternalObservation{}, nil
+	}
+	if err != nil {
+		return resource.ExternalObservation{}, errors.Wrap(err, errUserGet)
+	}
+
+	log.V(1).Info("Observe", "username", user.Username, "restricted", user.Restricted)
+
+	m.Status.Username = user.Username
+	m.Status.Email = user.Email
+	m.Status.Restricted = user.Restricted
+	m.Status.SetConditions(runtimev1alpha1.Available())
+	resource.SetBindable(m)
+
+	return resource.ExternalObservation{
+		ResourceExists: true,
+		ResourceUpToDate: user.Username == m.Spec.Username &&
+			user.Email == m.Spec.Email &&
+			user.Restricted == m.Spec.Restricted,
+	}, nil
+}
+
+// Create a new Linode User. Linode emails the User an invitation to set a
+// password.
+func (e *userExternal) Create(ctx context.Context, mg resource.Managed) (resource.ExternalCreation, error) {
+	m, ok := mg.(*linodev1alpha1.User)
+	if !ok {
+		return resource.ExternalCreation{}, errors.New(errNotUser)
+	}
+
+	m.Status.SetConditions(runtimev1alpha1.Creating())
+
+	user, err := e.client.CreateUser(ctx, linodego.UserCreateOptions{
+		Username:   m.Spec.Username,
+		Email:      m.Spec.Email,
+		Restricted: m.Spec.Restricted,
+	})
+	if err != nil {
+		return resource.ExternalCreation{}, errors.Wrap(err, errUserCreate)
+	}
+
+	m.Status.Username = user.Username
+
+	return resource.ExternalCreation{}, nil
+}
+
+// Update the Linode User to match the User, renaming it if its Username
+// changed.
+func (e *userExternal) Update(ctx context.Context, mg resource.Managed) (resource.ExternalUpdate, error) {
+	m, ok := mg.(*linodev1alpha1.User)
+	if !ok {
+		return resource.ExternalUpdate{}, errors.New(errNotUser)
+	}
+
+	restricted := m.Spec.Restricted
+	user, err := e.client.UpdateUser(ctx, m.Status.Username, linodego.UserUpdateOptions{
+		Username:   m.Spec.Username,
+		Email:      m.Spec.Email,
+		Restricted: &restricted,
+	})
+	if err != nil {
+		return resource.ExternalUpdate{}, errors.Wrap(err, errUserUpdate)
+	}
+
+	m.Status.Username = user.Username
+
+	return resource.ExternalUpdate{}, nil
+}
+
+// Delete the Linode User.
+func (e *userExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	m, ok := mg.(*linodev1alpha1.User)
+	if !ok {
+		return errors.New(errNotUser)
+	}
+
+	m.SetConditions(runtimev1alpha1.Deleting())
+	err := e.client.DeleteUser(ctx, m.Status.Username)
+	if clients.IsNotFound(err) {
+		return nil
+	}
+	return errors.Wrap(err, errUserDelete)
+}
@@ -0,0 +1,347 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/linode/linodego"
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/meta"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+
+	linodev1alpha1 "github.com/displague/stack-linode/api/v1alpha1"
+	"github.com/displague/stack-linode/clients"
+)
+
+const (
+	errNotUserGrants    = "managed resource is not a UserGrants"
+	errUserGrantsGet    = "cannot get UserGrants"
+	errUserGrantsUpdate = "cannot update UserGrants"
+	errUserGrantsRevoke = "cannot revoke UserGrants"
+	errGrantEntity      = "cannot resolve granted entity"
+	errGrantNoEntity    = "none of an ID, Instance reference or Image reference is set for granted entity"
+	errGrantImageID     = "cannot grant access to a public Image"
+)
+
+// UserGrantsController is responsible for adding the UserGrants
+// controller and its corresponding reconciler to the manager with any runtime configuration.
+type UserGrantsController struct {
+	Options Options
+}
+
+var (
+	userGrantsLog = ctrl.Log.WithName("usergrants.controller")
+)
+
+// SetupWithManager creates a new UserGrants Controller and adds it to the
+// Manager with default RBAC. The Manager will set fields on the Controller and
+// start it when the Manager is Started.
+func (c *UserGrantsController) SetupWithManager(mgr ctrl.Manager) error {
+	r := resource.NewManagedReconciler(mgr,
+		resource.ManagedKind(linodev1alpha1.UserGrantsGroupVersionKind),
+		resource.WithLongWait(c.Options.pollInterval()),
+		resource.WithExternalConnecter(withTracing(linodev1alpha1.UserGrantsKind, withManagementPolicy(linodev1alpha1.UserGrantsKind, &userGrantsConnecter{client: mgr.GetClient()}))))
+
+	name := strings.ToLower(fmt.Sprintf("%s.%s", linodev1alpha1.UserGrantsKind, linodev1alpha1.Group))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(c.Options.controllerOptions(linodev1alpha1.UserGrantsKind)).
+		For(&linodev1alpha1.UserGrants{}).
+		Complete(withPause(mgr.GetClient(), &linodev1alpha1.UserGrants{}, r))
+}
+
+type userGrantsConnecter struct {
+	client      client.Client
+	newClientFn func(credentials []byte) (linodego.Client, error)
+}
+
+// Connect to the supplied resource.Managed (presumed to be a UserGrants) by
+// using the Provider it references to create a new Linode API client.
+func (c *userGrantsConnecter) Connect(ctx context.Context, mg resource.Managed) (resource.ExternalClient, error) {
+	m, ok := mg.(*linodev1alpha1.UserGrants)
+	if !ok {
+		return nil, errors.New(errNotUserGrants)
+	}
+
+	client, err := connectLinode(ctx, c.client, m, c.newClientFn)
+	if err != nil {
+		return nil, err
+	}
+	return &userGrantsExternal{client: client, kube: c.client}, nil
+}
+
+type userGrantsExternal struct {
+	client linodego.Client
+	kube   client.Client
+}
+
+// Observe the grants of the Linode User. The UserGrants exists once it has
+// been applied to the User, until it is deleted and its grants are revoked.
+func (e *userGrantsExternal) Observe(ctx context.Context, mg resource.Managed) (resource.ExternalObservation, error) {
+	m, ok := mg.(*linodev1alpha1.UserGrants)
+	if !ok {
+		return resource.ExternalObservation{}, errors.New(errNotUserGrants)
+	}
+
+	if m.Status.Username == "" {
+		return resource.ExternalObservation{}, nil
+	}
+
+	ctx, log := managedLogger(ctx, userGrantsLog, m)
+	observed, err := clients.GetUserGrants(ctx, &e.client, m.Status.Username)
+	if clients.IsNotFound(err) {
+		return resource.ExternalObservation{}, nil
+	}
+	if err != nil {
+		return resource.ExternalObservation{}, errors.Wrap(err, errUserGrantsGet)
+	}
+
+	if meta.WasDeleted(m) {
+		return resource.ExternalObservation{ResourceExists: !userGrantsRevoked(m, *observed)}, nil
+	}
+
+	desired, _, err := e.desiredGrants(ctx, m)
+	if err != nil {
+		return resource.ExternalObservation{}, err
+	}
+
+	log.V(1).Info("Observe", "username", m.Status.Username)
+
+	m.Status.SetConditions(runtimev1alpha1.Available())
+	resource.SetBindable(m)
+
+	return resource.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: userGrantsUpToDate(desired, *observed),
+	}, nil
+}
+
+// Create the UserGrants by applying them to the Linode User.
+func (e *userGrantsExternal) Create(ctx context.Context, mg resource.Managed) (resource.ExternalCreation, error) {
+	m, ok := mg.(*linodev1alpha1.UserGrants)
+	if !ok {
+		return resource.ExternalCreation{}, errors.New(errNotUserGrants)
+	}
+
+	m.Status.SetConditions(runtimev1alpha1.Creating())
+
+	username, err := getUsername(ctx, e.kube, m.GetNamespace(), m.Spec.UserRef, m.Spec.Username)
+	if err != nil {
+		return resource.ExternalCreation{}, errors.Wrap(err, errUserGrantsUpdate)
+	}
+	m.Status.Username = username
+
+	return resource.ExternalCreation{}, e.apply(ctx, m)
+}
+
+// Update the grants of the Linode User to match the UserGrants.
+func (e *userGrantsExternal) Update(ctx context.Context, mg resource.Managed) (resource.ExternalUpdate, error) {
+	m, ok := mg.(*linodev1alpha1.UserGrants)
+	if !ok {
+		return resource.ExternalUpdate{}, errors.New(errNotUserGrants)
+	}
+
+	return resource.ExternalUpdate{}, e.apply(ctx, m)
+}
+
+// Delete the UserGrants by revoking the global grants and the grants to the
+// entities the Linode User was granted access to.
+func (e *userGrantsExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	m, ok := mg.(*linodev1alpha1.UserGrants)
+	if !ok {
+		return errors.New(errNotUserGrants)
+	}
+
+	m.SetConditions(runtimev1alpha1.Deleting())
+
+	grants := clients.UserGrants{Entities: map[clients.GrantEntityType][]clients.EntityUserGrant{}}
+	if m.Spec.Global != nil {
+		grants.Global = &clients.GlobalUserGrants{}
+	}
+	revokeStaleGrants(grants, m.Status.Entities)
+
+	err := clients.UpdateUserGrants(ctx, &e.client, m.Status.Username, grants)
+	if clients.IsNotFound(err) {
+		return nil
+	}
+	return errors.Wrap(err, errUserGrantsRevoke)
+}
+
+// apply the desired grants to the Linode User, revoking the grants to
+// entities that are no longer listed.
+func (e *userGrantsExternal) apply(ctx context.Context, m *linodev1alpha1.UserGrants) error {
+	desired, entities, err := e.desiredGrants(ctx, m)
+	if err != nil {
+		return errors.Wrap(err, errUserGrantsUpdate)
+	}
+	revokeStaleGrants(desired, m.Status.Entities)
+
+	if err := clients.UpdateUserGrants(ctx, &e.client, m.Status.Username, desired); err != nil {
+		return errors.Wrap(err, errUserGrantsUpdate)
+	}
+	m.Status.Entities = entities
+	return nil
+}
+
+// desiredGrants returns the grants of the UserGrants, and the entities they
+// grant access to, resolving any referenced entities.
+func (e *userGrantsExternal) desiredGrants(ctx context.Context, m *linodev1alpha1.UserGrants) (clients.UserGrants, []linodev1alpha1.UserGrantsObservedEntity, error) {
+	grants := clients.UserGrants{Entities: map[clients.GrantEntityType][]clients.EntityUserGrant{}}
+	if g := m.Spec.Global; g != nil {
+		grants.Global = &clients.GlobalUserGrants{
+			AddDomains:           g.AddDomains,
+			AddImages:            g.AddImages,
+			AddLinodes:           g.AddLinodes,
+			AddLongview:          g.AddLongview,
+			AddNodeBalancers:     g.AddNodeBalancers,
+			AddStackScripts:      g.AddStackScripts,
+			AddVolumes:           g.AddVolumes,
+			CancelAccount:        g.CancelAccount,
+			LongviewSubscription: g.LongviewSubscription,
+		}
+		if g.AccountAccess != "" {
+			access := g.AccountAccess
+			grants.Global.AccountAccess = &access
+		}
+	}
+
+	entities := make([]linodev1alpha1.UserGrantsObservedEntity, 0, len(m.Spec.Entities))
+	for _, g := range m.Spec.Entities {
+		id, err := e.getEntityID(ctx, m.GetNamespace(), g)
+		if err != nil {
+			return grants, nil, errors.Wrap(err, errGrantEntity)
+		}
+		permissions := g.Permissions
+		t := clients.GrantEntityType(g.Type)
+		grants.Entities[t] = append(grants.Entities[t], clients.EntityUserGrant{ID: id, Permissions: &permissions})
+		entities = append(entities, linodev1alpha1.UserGrantsObservedEntity{Type: g.Type, ID: id})
+	}
+	return grants, entities, nil
+}
+
+// getEntityID returns the Linode ID of the entity a UserEntityGrant grants
+// access to.
+func (e *userGrantsExternal) getEntityID(ctx context.Context, namespace string, g linodev1alpha1.UserEntityGrant) (int, error) {
+	switch {
+	case g.InstanceRef != nil:
+		return getInstanceID(ctx, e.kube, namespace, g.InstanceRef, 0)
+	case g.ImageRef != nil:
+		image, err := getImageID(ctx, e.kube, namespace, g.ImageRef, "")
+		if err != nil {
+			return 0, err
+		}
+		// Private Image IDs take the form "private/1234".
+		id, err := strconv.Atoi(strings.TrimPrefix(image, "private/"))
+		if err != nil {
+			return 0, errors.New(errGrantImageID)
+		}
+		return id, nil
+	case g.ID != 0:
+		return g.ID, nil
+	}
+	return 0, errors.New(errGrantNoEntity)
+}
+
+// revokeStaleGrants adds grants with no permissions to the supplied grants for
+// each previously granted entity that is no longer granted.
+func revokeStaleGrants(grants clients.UserGrants, previous []linodev1alpha1.UserGrantsObservedEntity) {
+	for _, p := range previous {
+		t := clients.GrantEntityType(p.Type)
+		if findEntityGrant(grants.Entities[t], p.ID) == nil {
+			grants.Entities[t] = append(grants.Entities[t], clients.EntityUserGrant{ID: p.ID})
+		}
+	}
+}
+
+// userGrantsUpToDate returns true if the observed grants satisfy the desired
+// grants.
+func userGrantsUpToDate(desired, observed clients.UserGrants) bool {
+	if desired.Global != nil {
+		if observed.Global == nil {
+			return false
+		}
+		d, o := *desired.Global, *observed.Global
+		if stringValue(d.AccountAccess) != stringValue(o.AccountAccess) {
+			return false
+		}
+		d.AccountAccess, o.AccountAccess = nil, nil
+		if d != o {
+			return false
+		}
+	}
+
+	for t, grants := range desired.Entities {
+		for _, g := range grants {
+			o := findEntityGrant(observed.Entities[t], g.ID)
+			if o == nil || stringValue(o.Permissions) != stringValue(g.Permissions) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// userGrantsRevoked returns true if the observed grants include none of the
+// grants of the supplied UserGrants. Grants to entities that no longer exist
+// are revoked.
+func userGrantsRevoked(m *linodev1alpha1.UserGrants, observed clients.UserGrants) bool {
+	if m.Spec.Global != nil && observed.Global != nil {
+		g := *observed.Global
+		if stringValue(g.AccountAccess) != "" {
+			return false
+		}
+		g.AccountAccess = nil
+		if g != (clients.GlobalUserGrants{}) {
+			return false
+		}
+	}
+
+	for _, p := range m.Status.Entities {
+		o := findEntityGrant(observed.Entities[clients.GrantEntityType(p.Type)], p.ID)
+		if o != nil && stringValue(o.Permissions) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// findEntityGrant returns the grant to the entity with the supplied ID, or nil
+// if there is none.
+func findEntityGrant(grants []clients.EntityUserGrant, id int) *clients.EntityUserGrant {
+	for i := range grants {
+		if grants[i].ID == id {
+			return &grants[i]
+		}
+	}
+	return nil
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
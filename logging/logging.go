@@ -0,0 +1,78 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package logging configures the logger of the controllers, and redacts
+// credentials from everything they log.
+package logging
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	ctrlzap "sigs.k8s.io/controller-runtime/pkg/log/zap"
+)
+
+// Supported log formats.
+const (
+	FormatJSON    = "json"
+	FormatConsole = "console"
+)
+
+// New returns a logger that writes messages at or above the supplied level
+// (debug, info, warn or error) in the supplied format. Credentials are
+// redacted from all messages and fields.
+func New(level, format string) (logr.Logger, error) {
+	var l zapcore.Level
+	if err := l.UnmarshalText([]byte(level)); err != nil {
+		return nil, errors.Wrapf(err, "invalid log level %q", level)
+	}
+	lvl := zap.NewAtomicLevelAt(l)
+
+	var enc zapcore.Encoder
+	switch format {
+	case FormatJSON:
+		enc = zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig())
+	case FormatConsole:
+		enc = zapcore.NewConsoleEncoder(zap.NewDevelopmentEncoderConfig())
+	default:
+		return nil, errors.Errorf("invalid log format %q: must be %s or %s", format, FormatJSON, FormatConsole)
+	}
+
+	return ctrlzap.New(func(o *ctrlzap.Options) {
+		o.Encoder = enc
+		o.Level = &lvl
+		o.ZapOpts = append(o.ZapOpts, zap.WrapCore(func(c zapcore.Core) zapcore.Core {
+			return &redactingCore{Core: c}
+		}))
+	}), nil
+}
+
+type contextKey struct{}
+
+// NewContext returns a context carrying the supplied logger, whose values
+// correlate everything logged while handling a request.
+func NewContext(ctx context.Context, log logr.Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, log)
+}
+
+// FromContext returns the logger carried by the supplied context, if any.
+func FromContext(ctx context.Context) (logr.Logger, bool) {
+	log, ok := ctx.Value(contextKey{}).(logr.Logger)
+	return log, ok
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logging
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Redacted replaces credentials in log messages and fields.
+const Redacted = "[REDACTED]"
+
+var (
+	// sensitiveKey matches the keys of fields and of structured values that
+	// hold credentials, such as rootPass, password or token.
+	sensitiveKey = regexp.MustCompile(`(?i)(pass(word)?|token|secret|credential|authorization|api[-_]?key|private[-_]?key)`)
+
+	// sensitiveValue matches credentials embedded in strings: Linode API
+	// tokens, and bearer tokens in Authorization headers.
+	sensitiveValue = regexp.MustCompile(`(?i)\b[0-9a-f]{64}\b|(bearer\s+)[^\s"']+`)
+)
+
+// A redactingCore redacts credentials from the messages and fields it writes.
+type redactingCore struct {
+	zapcore.Core
+}
+
+func (c *redactingCore) With(fields []zapcore.Field) zapcore.Core {
+	return &redactingCore{Core: c.Core.With(redactFields(fields))}
+}
+
+func (c *redactingCore) Check(e zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(e.Level) {
+		return ce.AddCore(e, c)
+	}
+	return ce
+}
+
+func (c *redactingCore) Write(e zapcore.Entry, fields []zapcore.Field) error {
+	e.Message = redactString(e.Message)
+	return c.Core.Write(e, redactFields(fields))
+}
+
+func redactFields(fields []zapcore.Field) []zapcore.Field {
+	redacted := make([]zapcore.Field, len(fields))
+	for i, f := range fields {
+		redacted[i] = redactField(f)
+	}
+	return redacted
+}
+
+func redactField(f zapcore.Field) zapcore.Field {
+	if isSensitiveKey(f.Key) {
+		return zap.String(f.Key, Redacted)
+	}
+
+	// Kubernetes objects, such as Secrets, are logged by namespace and name
+	// only. They are checked first because most of them are Stringers.
+	if o, ok := f.Interface.(metav1.Object); ok {
+		return zap.String(f.Key, objectName(o))
+	}
+
+	switch f.Type {
+	case zapcore.StringType:
+		return zap.String(f.Key, redactString(f.String))
+	case zapcore.ErrorType:
+		if err, ok := f.Interface.(error); ok {
+			return zap.String(f.Key, redactString(err.Error()))
+		}
+	case zapcore.StringerType:
+		if s, ok := f.Interface.(fmt.Stringer); ok {
+			return zap.String(f.Key, redactString(s.String()))
+		}
+	case zapcore.ReflectType:
+		return zap.Any(f.Key, redactValue(f.Interface))
+	}
+	return f
+}
+
+// objectName returns the namespace and name of the supplied object, such as
+// default/web, or only its name if it is not namespaced.
+func objectName(o metav1.Object) string {
+	if o.GetNamespace() == "" {
+		return o.GetName()
+	}
+	return o.GetNamespace() + "/" + o.GetName()
+}
+
+// redactValue returns a copy of the supplied value with credentials redacted,
+// by way of its JSON representation.
+func redactValue(v interface{}) interface{} {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return redactString(fmt.Sprintf("%+v", v))
+	}
+	var generic interface{}
+	if err := json.Unmarshal(b, &generic); err != nil {
+		return redactString(string(b))
+	}
+	return redactGeneric(generic)
+}
+
+func redactGeneric(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, e := range v {
+			if isSensitiveKey(k) {
+				v[k] = Redacted
+				continue
+			}
+			v[k] = redactGeneric(e)
+		}
+		return v
+	case []interface{}:
+		for i, e := range v {
+			v[i] = redactGeneric(e)
+		}
+		return v
+	case string:
+		return redactString(v)
+	}
+	return v
+}
+
+// isSensitiveKey returns true if the supplied key names a credential. Keys
+// naming the ID or expiry of a credential, such as tokenId, are not sensitive.
+func isSensitiveKey(k string) bool {
+	l := strings.ToLower(k)
+	if strings.HasSuffix(l, "id") || strings.HasSuffix(l, "expiry") || strings.HasSuffix(l, "ref") {
+		return false
+	}
+	return sensitiveKey.MatchString(k)
+}
+
+func redactString(s string) string {
+	return sensitiveValue.ReplaceAllString(s, "${1}"+Redacted)
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logging
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const token = "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+func TestRedact(t *testing.T) {
+	type spec struct {
+		Label    string `json:"label"`
+		RootPass string `json:"rootPass"`
+	}
+
+	cases := map[string]struct {
+		field zapcore.Field
+		want  interface{}
+	}{
+		"SensitiveKey": {
+			field: zap.String("rootPass", "hunter2"),
+			want:  Redacted,
+		},
+		"CredentialIDIsNotSensitive": {
+			field: zap.Int("tokenId", 42),
+			want:  int64(42),
+		},
+		"TokenInString": {
+			field: zap.String("msg", "using token "+token),
+			want:  "using token " + Redacted,
+		},
+		"BearerInError": {
+			field: zap.Error(errors.New("Authorization: Bearer s3cr3t failed")),
+			want:  "Authorization: Bearer " + Redacted + " failed",
+		},
+		"SensitiveKeyInStruct": {
+			field: zap.Any("spec", spec{Label: "web", RootPass: "hunter2"}),
+			want:  map[string]interface{}{"label": "web", "rootPass": Redacted},
+		},
+		"SecretObject": {
+			field: zap.Any("object", &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "provider-creds"},
+				Data:       map[string][]byte{"key": []byte(token)},
+			}),
+			want: "default/provider-creds",
+		},
+		"ReflectedObject": {
+			field: zap.Reflect("object", &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "provider-creds"},
+				StringData: map[string]string{"key": "hunter2"},
+			}),
+			want: "provider-creds",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			core, logs := observer.New(zapcore.DebugLevel)
+			log := zap.New(&redactingCore{Core: core})
+			log.Info("message with "+token, tc.field)
+
+			entry := logs.All()[0]
+			if strings.Contains(entry.Message, token) {
+				t.Errorf("message was not redacted: %s", entry.Message)
+			}
+			got := entry.ContextMap()[tc.field.Key]
+			if gotMap, ok := got.(map[string]interface{}); ok {
+				wantMap := tc.want.(map[string]interface{})
+				for k, v := range wantMap {
+					if gotMap[k] != v {
+						t.Errorf("%s.%s: want %v, got %v", tc.field.Key, k, v, gotMap[k])
+					}
+				}
+				return
+			}
+			if got != tc.want {
+				t.Errorf("want %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
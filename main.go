@@ -18,17 +18,28 @@ package main
 
 import (
 	"flag"
+	"fmt"
 	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
+	"go.opentelemetry.io/otel"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
-	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
 
 	// +kubebuilder:scaffold:imports
 	linodev1alpha1 "github.com/displague/stack-linode/api/v1alpha1"
+	"github.com/displague/stack-linode/catalog"
+	"github.com/displague/stack-linode/clients"
 	"github.com/displague/stack-linode/controllers"
+	"github.com/displague/stack-linode/logging"
+	"github.com/displague/stack-linode/tracing"
 )
 
 var (
@@ -42,11 +53,37 @@ func init() {
 }
 
 func main() {
-	var metricsAddr string
+	var metricsAddr, logLevel, logFormat, traceExporter, otlpEndpoint string
+	var enableWebhooks bool
+	var catalogRefreshInterval time.Duration
+	var o controllers.Options
+	perKind := kindCounts{}
 	flag.StringVar(&metricsAddr, "metrics-addr", ":8080", "The address the metric endpoint binds to.")
+	flag.StringVar(&logLevel, "log-level", "info", "The minimum level of log messages: debug, info, warn or error.")
+	flag.StringVar(&logFormat, "log-format", logging.FormatJSON, "The format of log messages: json or console.")
+	flag.StringVar(&traceExporter, "trace-exporter", tracing.ExporterNone, "Where spans of managed resource operations and Linode API requests are exported: none, stdout or otlp.")
+	flag.StringVar(&otlpEndpoint, "otlp-endpoint", tracing.DefaultOTLPEndpoint, "The OTLP/HTTP endpoint of the OpenTelemetry collector spans are exported to by the otlp exporter.")
+	flag.DurationVar(&o.PollInterval, "poll-interval", controllers.DefaultPollInterval, "How often managed resources are observed while they are stable.")
+	flag.DurationVar(&o.TransitionalPollInterval, "transitional-poll-interval", controllers.DefaultTransitionalPollInterval, "How often Instances are observed while their status is transitional, such as booting.")
+	flag.IntVar(&o.MaxConcurrentReconciles, "max-concurrent-reconciles", controllers.DefaultMaxConcurrentReconciles, "The number of resources of each kind that may be reconciled concurrently.")
+	flag.BoolVar(&enableWebhooks, "enable-webhooks", false, "Serve the defaulting and validating webhooks of Instances and Providers.")
+	flag.DurationVar(&catalogRefreshInterval, "catalog-refresh-interval", catalog.DefaultRefreshInterval, "How often the catalog of Linode regions, types and images is refreshed. Zero only uses the catalog embedded in the controller.")
+	flag.Var(perKind, "max-concurrent-reconciles-per-kind", "Overrides --max-concurrent-reconciles for some kinds, such as Instance=4,InstanceDisk=2.")
 	flag.Parse()
+	o.MaxConcurrentReconcilesPerKind = perKind
 
-	ctrl.SetLogger(zap.Logger(true))
+	log, err := logging.New(logLevel, logFormat)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	ctrl.SetLogger(log)
+
+	tp, err := tracing.NewTracerProvider(traceExporter, otlpEndpoint, os.Stdout)
+	if err != nil {
+		setupLog.Error(err, "Cannot create trace exporter")
+		os.Exit(1)
+	}
 
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{Scheme: scheme, MetricsBindAddress: metricsAddr})
 	if err != nil {
@@ -62,16 +99,64 @@ func main() {
 		setupLog.Error(err, "Cannot add APIs to scheme")
 		os.Exit(1)
 	}
+	setupLog.Info("Adding catalog")
+
+	c, err := catalog.NewFromSnapshot()
+	if err != nil {
+		setupLog.Error(err, "Cannot load catalog")
+		os.Exit(1)
+	}
+	o.Catalog = c
+
+	if catalogRefreshInterval > 0 {
+		r := &catalog.Refresher{
+			Catalog:  c,
+			Client:   clients.NewPublicClient(),
+			Interval: catalogRefreshInterval,
+			Log:      ctrl.Log.WithName("catalog"),
+		}
+		if err := mgr.Add(r); err != nil {
+			setupLog.Error(err, "Cannot add catalog refresher to manager")
+			os.Exit(1)
+		}
+	}
+
+	if tp != nil {
+		setupLog.Info("Adding tracing", "exporter", traceExporter)
+
+		if err := mgr.Add(&tracing.Shutdown{Provider: tp}); err != nil {
+			setupLog.Error(err, "Cannot add tracing to manager")
+			os.Exit(1)
+		}
+		otel.SetTracerProvider(tp)
+	}
+
 	setupLog.Info("Adding controllers")
 
 	// Setup all Controllers
-	if err := controllerSetupWithManager(mgr); err != nil {
+	if err := controllerSetupWithManager(mgr, o); err != nil {
 		setupLog.Error(err, "Cannot add controllers to manager")
 		os.Exit(1)
 	}
 
+	if enableWebhooks {
+		setupLog.Info("Adding webhooks")
+
+		if err := webhookSetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "Cannot add webhooks to manager")
+			os.Exit(1)
+		}
+	}
+
 	// +kubebuilder:scaffold:builder
 
+	setupLog.Info("Adding metrics")
+
+	if err := metrics.Registry.Register(controllers.NewManagedResourceCollector(mgr.GetClient())); err != nil {
+		setupLog.Error(err, "Cannot register metrics")
+		os.Exit(1)
+	}
+
 	setupLog.Info("starting manager")
 	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
 		setupLog.Error(err, "problem running manager")
@@ -79,11 +164,82 @@ func main() {
 	}
 }
 
-func controllerSetupWithManager(mgr manager.Manager) error {
-	if err := (&controllers.InstanceController{}).SetupWithManager(mgr); err != nil {
+func controllerSetupWithManager(mgr manager.Manager, o controllers.Options) error {
+	if err := (&controllers.ProviderController{Options: o}).SetupWithManager(mgr); err != nil {
+		return err
+	}
+
+	if err := (&controllers.ProviderConfigController{Options: o}).SetupWithManager(mgr); err != nil {
+		return err
+	}
+
+	if err := (&controllers.InstanceController{Options: o}).SetupWithManager(mgr); err != nil {
+		return err
+	}
+
+	if err := (&controllers.InstanceDiskController{Options: o}).SetupWithManager(mgr); err != nil {
+		return err
+	}
+
+	if err := (&controllers.InstanceConfigController{Options: o}).SetupWithManager(mgr); err != nil {
+		return err
+	}
+
+	if err := (&controllers.ImageController{Options: o}).SetupWithManager(mgr); err != nil {
 		return err
 	}
 
+	if err := (&controllers.InstanceBackupPolicyController{Options: o}).SetupWithManager(mgr); err != nil {
+		return err
+	}
+
+	if err := (&controllers.InstanceSnapshotController{Options: o}).SetupWithManager(mgr); err != nil {
+		return err
+	}
+
+	if err := (&controllers.SSHKeyController{Options: o}).SetupWithManager(mgr); err != nil {
+		return err
+	}
+
+	if err := (&controllers.UserController{Options: o}).SetupWithManager(mgr); err != nil {
+		return err
+	}
+
+	if err := (&controllers.UserGrantsController{Options: o}).SetupWithManager(mgr); err != nil {
+		return err
+	}
+
+	if err := (&controllers.PersonalAccessTokenController{Options: o}).SetupWithManager(mgr); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// kindCounts is a flag holding a comma separated list of kind=count pairs.
+type kindCounts map[string]int
+
+func (k kindCounts) String() string {
+	pairs := make([]string, 0, len(k))
+	for kind, n := range k {
+		pairs = append(pairs, fmt.Sprintf("%s=%d", kind, n))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+func (k kindCounts) Set(v string) error {
+	for _, pair := range strings.Split(v, ",") {
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 {
+			return fmt.Errorf("%q is not a kind=count pair", pair)
+		}
+		n, err := strconv.Atoi(kv[1])
+		if err != nil || n < 1 {
+			return fmt.Errorf("%q is not a positive count", kv[1])
+		}
+		k[kv[0]] = n
+	}
 	return nil
 }
 
@@ -97,5 +253,21 @@ func addToScheme(scheme *runtime.Scheme) error {
 		return err
 	}
 
+	if err := batchv1.AddToScheme(scheme); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func webhookSetupWithManager(mgr manager.Manager) error {
+	if err := (&linodev1alpha1.Instance{}).SetupWebhookWithManager(mgr); err != nil {
+		return err
+	}
+
+	if err := (&linodev1alpha1.Provider{}).SetupWebhookWithManager(mgr); err != nil {
+		return err
+	}
+
 	return nil
 }
@@ -0,0 +1,113 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tracing configures the OpenTelemetry SDK to export spans of managed
+// resource operations and Linode API requests.
+package tracing
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+)
+
+// Supported exporters.
+const (
+	ExporterNone   = "none"
+	ExporterStdout = "stdout"
+	ExporterOTLP   = "otlp"
+)
+
+// DefaultOTLPEndpoint is the OTLP/HTTP endpoint of a local OpenTelemetry
+// collector.
+const DefaultOTLPEndpoint = "http://localhost:4318"
+
+// ServiceName identifies the controllers in exported spans.
+const ServiceName = "stack-linode"
+
+// InstrumentationName names the tracer of the controllers.
+const InstrumentationName = "github.com/displague/stack-linode"
+
+// shutdownTimeout is how long queued spans may take to be exported when the
+// controllers stop.
+const shutdownTimeout = 10 * time.Second
+
+const (
+	errCreateExporter = "cannot create trace exporter"
+	errOTLPEndpoint   = "invalid OTLP endpoint"
+)
+
+// NewTracerProvider returns a TracerProvider that batches spans and exports
+// them using the named exporter (none, stdout or otlp). Spans are exported to
+// the supplied OTLP/HTTP endpoint by the otlp exporter, and to the supplied
+// writer by the stdout exporter. The none exporter returns a nil
+// TracerProvider, leaving the global no-op TracerProvider in place.
+func NewTracerProvider(name, endpoint string, w io.Writer) (*sdktrace.TracerProvider, error) {
+	var e sdktrace.SpanExporter
+	var err error
+	switch name {
+	case ExporterNone:
+		return nil, nil
+	case ExporterStdout:
+		e, err = stdouttrace.New(stdouttrace.WithWriter(w))
+	case ExporterOTLP:
+		e, err = newOTLPExporter(endpoint)
+	default:
+		return nil, errors.Errorf("invalid trace exporter %q: must be %s, %s or %s", name, ExporterNone, ExporterStdout, ExporterOTLP)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, errCreateExporter)
+	}
+
+	r := resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceNameKey.String(ServiceName))
+	return sdktrace.NewTracerProvider(sdktrace.WithBatcher(e), sdktrace.WithResource(r)), nil
+}
+
+// newOTLPExporter returns an exporter to the supplied OTLP/HTTP endpoint, such
+// as http://localhost:4318. Plain http endpoints are exported to without TLS.
+func newOTLPExporter(endpoint string) (sdktrace.SpanExporter, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil, errors.Errorf("%s %q: must be an http or https URL", errOTLPEndpoint, endpoint)
+	}
+	opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(u.Host)}
+	if u.Scheme == "http" {
+		opts = append(opts, otlptracehttp.WithInsecure())
+	}
+	return otlptracehttp.New(context.Background(), opts...)
+}
+
+// A Shutdown flushes and shuts down a TracerProvider when the controller
+// manager it was added to as a Runnable stops.
+type Shutdown struct {
+	Provider *sdktrace.TracerProvider
+}
+
+// Start waits for the manager to stop, then shuts down the TracerProvider.
+func (s *Shutdown) Start(stop <-chan struct{}) error {
+	<-stop
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	return s.Provider.Shutdown(ctx)
+}
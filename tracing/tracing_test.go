@@ -0,0 +1,71 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestNewTracerProvider(t *testing.T) {
+	cases := map[string]struct {
+		name     string
+		endpoint string
+		wantNil  bool
+		wantErr  bool
+	}{
+		"None":            {name: ExporterNone, wantNil: true},
+		"Stdout":          {name: ExporterStdout},
+		"OTLP":            {name: ExporterOTLP, endpoint: DefaultOTLPEndpoint},
+		"OTLPNoScheme":    {name: ExporterOTLP, endpoint: "localhost:4318", wantNil: true, wantErr: true},
+		"UnknownExporter": {name: "jaeger", wantNil: true, wantErr: true},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			tp, err := NewTracerProvider(tc.name, tc.endpoint, &bytes.Buffer{})
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("NewTracerProvider(...): want error %t, got %v", tc.wantErr, err)
+			}
+			if (tp == nil) != tc.wantNil {
+				t.Fatalf("NewTracerProvider(...): want nil TracerProvider %t, got %v", tc.wantNil, tp)
+			}
+		})
+	}
+}
+
+func TestShutdown(t *testing.T) {
+	out := &bytes.Buffer{}
+	tp, err := NewTracerProvider(ExporterStdout, "", out)
+	if err != nil {
+		t.Fatalf("NewTracerProvider(...): %v", err)
+	}
+
+	_, span := tp.Tracer(InstrumentationName).Start(context.Background(), "Instance Observe")
+	span.End()
+
+	stop := make(chan struct{})
+	close(stop)
+	if err := (&Shutdown{Provider: tp}).Start(stop); err != nil {
+		t.Fatalf("Start(...): %v", err)
+	}
+	if !strings.Contains(out.String(), `"Name":"Instance Observe"`) || !strings.Contains(out.String(), ServiceName) {
+		t.Errorf("want the span exported on shutdown, got %s", out.String())
+	}
+}